@@ -0,0 +1,62 @@
+package confluence
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MaxAncestors is the v2 ancestors endpoint's page size, which is far deeper
+// than any real page tree, so one call returns the whole chain.
+const MaxAncestors = 250
+
+// GetPageAncestors returns the content above a page, root first, so the last
+// entry is the parent. An empty list means the page sits at the top of its
+// space.
+func (c *Client) GetPageAncestors(pageID string) ([]ContentRef, error) {
+	if c.isServer() {
+		return c.getPageAncestorsServer(pageID)
+	}
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(MaxAncestors))
+	body, err := c.do("GET", "/pages/"+pageID+"/ancestors", query)
+	if err != nil {
+		return nil, fmt.Errorf("getting page ancestors: %w", err)
+	}
+	var raw struct {
+		Results []struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("parsing page ancestors: %w", err)
+	}
+
+	// The endpoint returns IDs only; page titles come from one bulk fetch.
+	// Folders and other non-page ancestors keep an empty title.
+	var pageIDs []string
+	for _, ancestor := range raw.Results {
+		if ancestor.Type == "page" {
+			pageIDs = append(pageIDs, ancestor.ID)
+		}
+	}
+	titles := map[string]string{}
+	if len(pageIDs) > 0 {
+		pages, err := c.GetPages(GetPagesOptions{PageIDs: pageIDs})
+		if err != nil {
+			return nil, err
+		}
+		for _, page := range pages.Pages {
+			titles[page.ID] = page.Title
+		}
+	}
+
+	ancestors := make([]ContentRef, len(raw.Results))
+	for i, ancestor := range raw.Results {
+		ancestors[i] = ContentRef{ID: ancestor.ID, Type: ancestor.Type, Title: titles[ancestor.ID]}
+	}
+	return ancestors, nil
+}
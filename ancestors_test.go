@@ -0,0 +1,68 @@
+package confluence
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGetPageAncestors(t *testing.T) {
+	var bulkQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/wiki/api/v2/pages/400/ancestors":
+			if r.URL.Query().Get("limit") != "250" {
+				t.Errorf("limit = %q", r.URL.Query().Get("limit"))
+			}
+			_, _ = w.Write([]byte(`{"results":[{"id":"1","type":"page"},{"id":"9","type":"folder"},{"id":"2","type":"page"}]}`))
+		case "/wiki/api/v2/pages":
+			bulkQuery = r.URL.Query()
+			_, _ = w.Write([]byte(`{"results":[{"id":"2","title":"Decisions"},{"id":"1","title":"Home"}],"_links":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ancestors, err := newTestClient(srv.URL).GetPageAncestors("400")
+	if err != nil {
+		t.Fatalf("GetPageAncestors: %v", err)
+	}
+	if bulkQuery.Get("id") != "1,2" {
+		t.Errorf("bulk ids = %q", bulkQuery.Get("id"))
+	}
+	want := []ContentRef{{ID: "1", Type: "page", Title: "Home"}, {ID: "9", Type: "folder"}, {ID: "2", Type: "page", Title: "Decisions"}}
+	if len(ancestors) != len(want) {
+		t.Fatalf("ancestors = %+v", ancestors)
+	}
+	for i := range want {
+		if ancestors[i] != want[i] {
+			t.Errorf("ancestors[%d] = %+v, want %+v", i, ancestors[i], want[i])
+		}
+	}
+}
+
+func TestServerGetPageAncestors(t *testing.T) {
+	queries := map[string]url.Values{}
+	srv := serverTestServer(t, map[string]string{
+		"/confluence/rest/api/content/65601": "server_page_get.json",
+	}, queries)
+	defer srv.Close()
+
+	ancestors, err := newServerTestClient(srv.URL).GetPageAncestors("65601")
+	if err != nil {
+		t.Fatalf("GetPageAncestors: %v", err)
+	}
+	if got := queries["/confluence/rest/api/content/65601"].Get("expand"); got != "ancestors" {
+		t.Errorf("expand = %q", got)
+	}
+	if len(ancestors) != 1 || ancestors[0] != (ContentRef{ID: "65537", Type: "page", Title: "Engineering Home"}) {
+		t.Errorf("ancestors = %+v", ancestors)
+	}
+	// An escaped ID stays one path segment instead of reaching /content/65601.
+	if _, err := newServerTestClient(srv.URL).GetPageAncestors("65601?expand=body"); err == nil {
+		t.Error("unescaped page ID reached /content/65601")
+	}
+}
@@ -0,0 +1,227 @@
+package confluence
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultOAuth2TokenURL is the Atlassian OAuth 2.0 (3LO) token endpoint.
+	DefaultOAuth2TokenURL = "https://auth.atlassian.com/oauth/token"
+	// DefaultAPIGatewayURL is the Atlassian API gateway used for cloud-id based URLs.
+	DefaultAPIGatewayURL = "https://api.atlassian.com"
+
+	oauth2ExpirySkew = 30 * time.Second
+)
+
+// Authenticator decorates outgoing API requests with credentials.
+type Authenticator interface {
+	Authorize(req *http.Request) error
+}
+
+// TokenRefresher is implemented by authenticators that can recover from a 401
+// by obtaining a fresh access token. The client calls RefreshRejected with the
+// Authorization header the 401 answered and retries once; an implementation
+// should skip the refresh when its token already changed since that header was
+// sent, so concurrent 401s share one refresh.
+type TokenRefresher interface {
+	RefreshRejected(rejected string) error
+}
+
+// BasicAuth authenticates with an Atlassian account email and API token.
+type BasicAuth struct {
+	Email string
+	Token string
+}
+
+func (a BasicAuth) Authorize(req *http.Request) error {
+	req.SetBasicAuth(a.Email, a.Token)
+	return nil
+}
+
+// BearerAuth authenticates with a static bearer access token.
+type BearerAuth struct {
+	Token string
+}
+
+func (a BearerAuth) Authorize(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// OAuth2Token is the rotating token pair issued by the Atlassian token endpoint.
+type OAuth2Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// OAuth2Options configures NewOAuth2Auth. ClientID and ClientSecret are only
+// needed to refresh Token; TokenURL and HTTPClient default to the Atlassian
+// token endpoint and a client with a 30s timeout.
+type OAuth2Options struct {
+	ClientID     string
+	ClientSecret string
+	TokenURL     string
+	Token        OAuth2Token
+	HTTPClient   *http.Client
+	// OnRefresh is called with the rotated token after every successful
+	// refresh, typically to persist it. Its error does not fail the request:
+	// the old refresh token is already spent, so the rotated token stays in use
+	// and OnRefresh has to report its own failures.
+	OnRefresh func(OAuth2Token) error
+}
+
+// OAuth2Auth authenticates with an OAuth 2.0 (3LO) bearer token and refreshes
+// it through the refresh-token grant when it expires or is rejected.
+type OAuth2Auth struct {
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	httpClient   *http.Client
+	onRefresh    func(OAuth2Token) error
+
+	mu    sync.Mutex
+	token OAuth2Token
+}
+
+func NewOAuth2Auth(opts OAuth2Options) *OAuth2Auth {
+	tokenURL := opts.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultOAuth2TokenURL
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &OAuth2Auth{
+		clientID:     opts.ClientID,
+		clientSecret: opts.ClientSecret,
+		tokenURL:     tokenURL,
+		httpClient:   httpClient,
+		onRefresh:    opts.OnRefresh,
+		token:        opts.Token,
+	}
+}
+
+// Token returns the current token pair, including any rotated refresh token.
+func (a *OAuth2Auth) Token() OAuth2Token {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.token
+}
+
+func (a *OAuth2Auth) Authorize(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	expired := !a.token.ExpiresAt.IsZero() && time.Now().Add(oauth2ExpirySkew).After(a.token.ExpiresAt)
+	if expired && a.token.RefreshToken != "" {
+		if err := a.refreshLocked(); err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.token.AccessToken)
+	return nil
+}
+
+// Refresh exchanges the refresh token for a new access token. Atlassian rotates
+// refresh tokens, so the returned refresh token replaces the stored one.
+func (a *OAuth2Auth) Refresh() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.refreshLocked()
+}
+
+// RefreshRejected refreshes unless the token changed while this request waited
+// for the lock, so concurrent 401s share one refresh instead of each rotating
+// the refresh token.
+func (a *OAuth2Auth) RefreshRejected(rejected string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if rejected != "Bearer "+a.token.AccessToken {
+		return nil
+	}
+	return a.refreshLocked()
+}
+
+func (a *OAuth2Auth) refreshLocked() error {
+	if a.token.RefreshToken == "" {
+		return &APIError{StatusCode: http.StatusUnauthorized, Message: "oauth access token rejected and no refresh token is available"}
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     a.clientID,
+		"client_secret": a.clientSecret,
+		"refresh_token": a.token.RefreshToken,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding refresh request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", a.tokenURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("creating refresh request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing refresh request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading refresh response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		// Any refresh failure means the stored grant is unusable; surface it as an auth failure.
+		return &APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    fmt.Sprintf("oauth token refresh failed (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body))),
+		}
+	}
+
+	var raw struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return fmt.Errorf("parsing refresh response: %w", err)
+	}
+	if raw.AccessToken == "" {
+		return fmt.Errorf("parsing refresh response: missing access_token")
+	}
+
+	next := OAuth2Token{AccessToken: raw.AccessToken, RefreshToken: a.token.RefreshToken}
+	if raw.RefreshToken != "" {
+		next.RefreshToken = raw.RefreshToken
+	}
+	if raw.ExpiresIn > 0 {
+		next.ExpiresAt = time.Now().Add(time.Duration(raw.ExpiresIn) * time.Second)
+	}
+	a.token = next
+	if a.onRefresh != nil {
+		_ = a.onRefresh(next)
+	}
+	return nil
+}
+
+// CloudAPIBaseURL returns the API gateway base URL for an OAuth 2.0 site.
+func CloudAPIBaseURL(gatewayURL, cloudID string) string {
+	if gatewayURL == "" {
+		gatewayURL = DefaultAPIGatewayURL
+	}
+	return strings.TrimRight(gatewayURL, "/") + "/ex/confluence/" + cloudID
+}
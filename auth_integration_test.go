@@ -133,6 +133,83 @@ func TestAuthLoginRejectsMixedInputModes_Integration(t *testing.T) {
 	}
 }
 
+func TestAuthLoginOAuthRefreshRotation_Integration(t *testing.T) {
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req["refresh_token"] != "refresh-1" {
+			t.Errorf("refresh_token = %q, want %q", req["refresh_token"], "refresh-1")
+		}
+		writeJSONResponse(w, []byte(`{"access_token":"access-2","refresh_token":"refresh-2","expires_in":3600}`))
+	}))
+	defer tokenSrv.Close()
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ex/confluence/cloud-1/wiki/api/v2/spaces" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer access-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSONResponse(w, []byte(`{"results":[{"id":"1","key":"DEV","name":"Development","type":"global","status":"current"}]}`))
+	}))
+	defer apiSrv.Close()
+
+	tmp := t.TempDir()
+	configDir := filepath.Join(tmp, "config")
+	binPath := buildBinary(t, tmp)
+	env := append(envForIntegration(configDir),
+		"CONFLUENCE_API_GATEWAY_URL="+apiSrv.URL,
+		"CONFLUENCE_OAUTH_TOKEN_URL="+tokenSrv.URL,
+	)
+
+	stdinJSON := `{"oauth":{"cloudId":"cloud-1","accessToken":"access-1","refreshToken":"refresh-1","clientId":"client","clientSecret":"secret","expiresAt":"2020-01-01T00:00:00Z"}}`
+	stdout, stderr, err := runBinary(binPath, []string{"auth", "login", "--stdin-json"}, stdinJSON, env...)
+	if err != nil {
+		t.Fatalf("auth login failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+
+	stdout, stderr, err = runBinary(binPath, []string{"spaces", "list"}, "", env...)
+	if err != nil {
+		t.Fatalf("spaces list failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+	if !strings.Contains(stdout, `"key": "DEV"`) {
+		t.Fatalf("unexpected spaces list output: %s", stdout)
+	}
+
+	body, err := os.ReadFile(filepath.Join(configDir, "credentials.json"))
+	if err != nil {
+		t.Fatalf("read credentials: %v", err)
+	}
+	var stored struct {
+		OAuth struct {
+			AccessToken  string `json:"accessToken"`
+			RefreshToken string `json:"refreshToken"`
+		} `json:"oauth"`
+	}
+	if err := json.Unmarshal(body, &stored); err != nil {
+		t.Fatalf("parse credentials: %v", err)
+	}
+	if stored.OAuth.AccessToken != "access-2" || stored.OAuth.RefreshToken != "refresh-2" {
+		t.Fatalf("stored oauth token was not rotated: %s", body)
+	}
+}
+
+func TestAuthLoginOAuthValidation_Integration(t *testing.T) {
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+
+	_, stderr, exitCode, err := runBinaryWithExitCode(binPath, []string{"auth", "login", "--stdin-json"},
+		`{"oauth":{"cloudId":"cloud-1","accessToken":"a","refreshToken":"r"}}`, envForIntegration(filepath.Join(tmp, "config"))...)
+	if err == nil || exitCode != 2 {
+		t.Fatalf("exit code = %d, want 2 (err=%v)", exitCode, err)
+	}
+	if !strings.Contains(stderr, "refreshToken requires clientId and clientSecret") {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+}
+
 func integrationServer() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/wiki/api/v2/spaces" {
@@ -0,0 +1,264 @@
+package confluence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBearerAuthHeader(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(Options{BaseURL: srv.URL, Authenticator: BearerAuth{Token: "pat-123"}})
+	if _, err := client.ListSpaces(ListSpacesOptions{}); err != nil {
+		t.Fatalf("ListSpaces: %v", err)
+	}
+	if gotAuth != "Bearer pat-123" {
+		t.Fatalf("Authorization = %q, want %q", gotAuth, "Bearer pat-123")
+	}
+}
+
+func TestCloudIDBaseURL(t *testing.T) {
+	var gotPaths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(Options{
+		CloudID:       "cloud-1",
+		APIGatewayURL: srv.URL,
+		Authenticator: BearerAuth{Token: "tok"},
+	})
+	if _, err := client.ListSpaces(ListSpacesOptions{}); err != nil {
+		t.Fatalf("ListSpaces: %v", err)
+	}
+	if _, err := client.SearchPages(PageSearchOptions{Query: "runbook"}); err != nil {
+		t.Fatalf("SearchPages: %v", err)
+	}
+
+	want := []string{"/ex/confluence/cloud-1/wiki/api/v2/spaces", "/ex/confluence/cloud-1/wiki/rest/api/search"}
+	if strings.Join(gotPaths, ",") != strings.Join(want, ",") {
+		t.Fatalf("paths = %v, want %v", gotPaths, want)
+	}
+}
+
+func newTokenServer(t *testing.T, accessToken, refreshToken string, gotRefresh *string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode refresh request: %v", err)
+		}
+		if req["grant_type"] != "refresh_token" || req["client_id"] != "client" || req["client_secret"] != "secret" {
+			t.Errorf("unexpected refresh request: %v", req)
+		}
+		*gotRefresh = req["refresh_token"]
+		_, _ = w.Write([]byte(`{"access_token":"` + accessToken + `","refresh_token":"` + refreshToken + `","expires_in":3600}`))
+	}))
+}
+
+func TestOAuth2RefreshesExpiredToken(t *testing.T) {
+	var usedRefresh string
+	tokenSrv := newTokenServer(t, "access-2", "refresh-2", &usedRefresh)
+	defer tokenSrv.Close()
+
+	var gotAuth string
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer apiSrv.Close()
+
+	var rotated OAuth2Token
+	auth := NewOAuth2Auth(OAuth2Options{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     tokenSrv.URL,
+		Token:        OAuth2Token{AccessToken: "access-1", RefreshToken: "refresh-1", ExpiresAt: time.Now().Add(-time.Minute)},
+		OnRefresh: func(token OAuth2Token) error {
+			rotated = token
+			return nil
+		},
+	})
+	client := NewClient(Options{BaseURL: apiSrv.URL, Authenticator: auth})
+	if _, err := client.ListSpaces(ListSpacesOptions{}); err != nil {
+		t.Fatalf("ListSpaces: %v", err)
+	}
+
+	if usedRefresh != "refresh-1" {
+		t.Fatalf("refresh token sent = %q, want %q", usedRefresh, "refresh-1")
+	}
+	if gotAuth != "Bearer access-2" {
+		t.Fatalf("Authorization = %q, want %q", gotAuth, "Bearer access-2")
+	}
+	if rotated.RefreshToken != "refresh-2" || rotated.AccessToken != "access-2" {
+		t.Fatalf("rotated token = %+v", rotated)
+	}
+	if rotated.ExpiresAt.Before(time.Now()) {
+		t.Fatalf("rotated token already expired: %v", rotated.ExpiresAt)
+	}
+}
+
+func TestOAuth2RefreshesOn401AndRetries(t *testing.T) {
+	var usedRefresh string
+	tokenSrv := newTokenServer(t, "access-2", "refresh-2", &usedRefresh)
+	defer tokenSrv.Close()
+
+	calls := 0
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "Bearer access-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer apiSrv.Close()
+
+	auth := NewOAuth2Auth(OAuth2Options{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     tokenSrv.URL,
+		Token:        OAuth2Token{AccessToken: "revoked", RefreshToken: "refresh-1"},
+	})
+	client := NewClient(Options{BaseURL: apiSrv.URL, Authenticator: auth})
+	if _, err := client.ListSpaces(ListSpacesOptions{}); err != nil {
+		t.Fatalf("ListSpaces: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("API calls = %d, want 2", calls)
+	}
+	if got := auth.Token().RefreshToken; got != "refresh-2" {
+		t.Fatalf("refresh token = %q, want rotated %q", got, "refresh-2")
+	}
+}
+
+func TestOAuth2ConcurrentRejectionsRefreshOnce(t *testing.T) {
+	var refreshes atomic.Int32
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := refreshes.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		_, _ = fmt.Fprintf(w, `{"access_token":"access-%d","refresh_token":"refresh-%d","expires_in":3600}`, n+1, n+1)
+	}))
+	defer tokenSrv.Close()
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer revoked" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer apiSrv.Close()
+
+	auth := NewOAuth2Auth(OAuth2Options{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     tokenSrv.URL,
+		Token:        OAuth2Token{AccessToken: "revoked", RefreshToken: "refresh-1"},
+	})
+	client := NewClient(Options{BaseURL: apiSrv.URL, Authenticator: auth})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.ListSpaces(ListSpacesOptions{})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("ListSpaces: %v", err)
+		}
+	}
+	if n := refreshes.Load(); n != 1 {
+		t.Fatalf("refreshes = %d, want 1", n)
+	}
+	if got := auth.Token().RefreshToken; got != "refresh-2" {
+		t.Fatalf("refresh token = %q, want %q", got, "refresh-2")
+	}
+}
+
+func TestOAuth2KeepsRotatedTokenWhenSaveFails(t *testing.T) {
+	var usedRefresh string
+	tokenSrv := newTokenServer(t, "access-2", "refresh-2", &usedRefresh)
+	defer tokenSrv.Close()
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer apiSrv.Close()
+
+	saves := 0
+	auth := NewOAuth2Auth(OAuth2Options{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     tokenSrv.URL,
+		Token:        OAuth2Token{AccessToken: "revoked", RefreshToken: "refresh-1"},
+		OnRefresh: func(OAuth2Token) error {
+			saves++
+			return errors.New("disk full")
+		},
+	})
+	client := NewClient(Options{BaseURL: apiSrv.URL, Authenticator: auth})
+	if _, err := client.ListSpaces(ListSpacesOptions{}); err != nil {
+		t.Fatalf("ListSpaces after a failed save: %v", err)
+	}
+	if saves != 1 || auth.Token().RefreshToken != "refresh-2" {
+		t.Fatalf("saves = %d, token = %+v; want the rotated token kept", saves, auth.Token())
+	}
+}
+
+func TestOAuth2RefreshFailureIsAuthError(t *testing.T) {
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"unauthorized_client"}`))
+	}))
+	defer tokenSrv.Close()
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer apiSrv.Close()
+
+	auth := NewOAuth2Auth(OAuth2Options{
+		TokenURL: tokenSrv.URL,
+		Token:    OAuth2Token{AccessToken: "revoked", RefreshToken: "refresh-1"},
+	})
+	client := NewClient(Options{BaseURL: apiSrv.URL, Authenticator: auth})
+	_, err := client.ListSpaces(ListSpacesOptions{})
+	if err == nil {
+		t.Fatal("expected refresh failure")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error type = %T, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", apiErr.StatusCode, http.StatusUnauthorized)
+	}
+}
@@ -1,7 +1,6 @@
 package confluence
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,14 +12,24 @@ import (
 
 type Client struct {
 	baseURL    string
-	authHeader string
+	flavor     string
+	auth       Authenticator
 	httpClient *http.Client
 }
 
 type Options struct {
-	BaseURL    string
-	Email      string
-	Token      string
+	BaseURL string
+	Email   string
+	Token   string
+	// Authenticator overrides Email/Token basic auth when set.
+	Authenticator Authenticator
+	// CloudID switches to the OAuth 2.0 API gateway URL for that site and
+	// takes precedence over BaseURL.
+	CloudID       string
+	APIGatewayURL string
+	// Flavor selects the API family: FlavorCloud (default) or FlavorServer
+	// for Data Center / Server REST v1 endpoints.
+	Flavor     string
 	HTTPClient *http.Client
 	Timeout    time.Duration
 }
@@ -45,12 +54,29 @@ func NewClient(opts Options) *Client {
 		httpClient = &http.Client{Timeout: opts.Timeout}
 	}
 
-	baseURL := normalizeBaseURL(opts.BaseURL)
-	auth := base64.StdEncoding.EncodeToString([]byte(opts.Email + ":" + opts.Token))
+	baseURL := opts.BaseURL
+	if opts.CloudID != "" {
+		baseURL = CloudAPIBaseURL(opts.APIGatewayURL, opts.CloudID)
+	}
+
+	auth := opts.Authenticator
+	if auth == nil {
+		auth = BasicAuth{Email: opts.Email, Token: opts.Token}
+	}
+
+	if opts.Flavor == FlavorServer {
+		return &Client{
+			baseURL:    normalizeServerBaseURL(baseURL),
+			flavor:     FlavorServer,
+			auth:       auth,
+			httpClient: httpClient,
+		}
+	}
 
 	return &Client{
-		baseURL:    baseURL,
-		authHeader: "Basic " + auth,
+		baseURL:    normalizeBaseURL(baseURL),
+		flavor:     FlavorCloud,
+		auth:       auth,
 		httpClient: httpClient,
 	}
 }
@@ -71,27 +97,13 @@ func (c *Client) do(method, path string, query url.Values) ([]byte, error) {
 		u += "?" + query.Encode()
 	}
 
-	req, err := http.NewRequest(method, u, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Authorization", c.authHeader)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+	statusCode, body, err := c.execute(method, u)
 	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		apiErr := &APIError{StatusCode: resp.StatusCode}
+	if statusCode >= 400 {
+		apiErr := &APIError{StatusCode: statusCode}
 		// Try to parse error message from response
 		var errResp struct {
 			Message string `json:"message"`
@@ -124,27 +136,13 @@ func (c *Client) doV1(method, path string, query url.Values) ([]byte, error) {
 		u += "?" + query.Encode()
 	}
 
-	req, err := http.NewRequest(method, u, nil)
+	statusCode, body, err := c.execute(method, u)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", c.authHeader)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		apiErr := &APIError{StatusCode: resp.StatusCode}
+	if statusCode >= 400 {
+		apiErr := &APIError{StatusCode: statusCode}
 		var errResp struct {
 			Message string `json:"message"`
 		}
@@ -159,6 +157,52 @@ func (c *Client) doV1(method, path string, query url.Values) ([]byte, error) {
 	return body, nil
 }
 
+// execute sends an authorized request and returns the status code and body.
+// A 401 triggers one token refresh and retry when the authenticator supports it.
+func (c *Client) execute(method, u string) (int, []byte, error) {
+	statusCode, body, authorization, err := c.roundTrip(method, u)
+	if err != nil || statusCode != http.StatusUnauthorized {
+		return statusCode, body, err
+	}
+
+	refresher, ok := c.auth.(TokenRefresher)
+	if !ok {
+		return statusCode, body, nil
+	}
+	if err := refresher.RefreshRejected(authorization); err != nil {
+		return 0, nil, err
+	}
+	statusCode, body, _, err = c.roundTrip(method, u)
+	return statusCode, body, err
+}
+
+// roundTrip sends one authorized request. It also returns the Authorization
+// header it sent, which execute hands back to the refresher on a 401.
+func (c *Client) roundTrip(method, u string) (int, []byte, string, error) {
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return 0, nil, "", fmt.Errorf("creating request: %w", err)
+	}
+
+	if err := c.auth.Authorize(req); err != nil {
+		return 0, nil, "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	authorization := req.Header.Get("Authorization")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, "", fmt.Errorf("executing request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, "", fmt.Errorf("reading response: %w", err)
+	}
+	return resp.StatusCode, body, authorization, nil
+}
+
 // paginatedResponse is the raw Confluence v2 paginated response
 type paginatedResponse struct {
 	Results json.RawMessage `json:"results"`
@@ -3,10 +3,13 @@ package confluence
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 // testServer creates an httptest.Server that serves fixture files based on URL path.
@@ -471,3 +474,212 @@ func TestClientBaseURLFromRestAPIPath(t *testing.T) {
 		t.Fatalf("SearchPages with /wiki/rest/api base path: %v", err)
 	}
 }
+
+func TestGetPagesChunksAndReportsNotFound(t *testing.T) {
+	var requests []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/api/v2/pages" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		requests = append(requests, r.URL.Query())
+		var results []string
+		for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+			if id != "2" {
+				results = append(results, `{"id":"`+id+`","title":"Page `+id+`","spaceId":"S1","status":"current"}`)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[` + strings.Join(results, ",") + `],"_links":{}}`))
+	}))
+	defer srv.Close()
+
+	ids := []string{"3", "2", "not-an-id", "1", "3"}
+	for i := 10; i < 260; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+	client := newTestClient(srv.URL)
+	result, err := client.GetPages(GetPagesOptions{PageIDs: ids, BodyFormat: "view"})
+	if err != nil {
+		t.Fatalf("GetPages: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 chunked requests, got %d", len(requests))
+	}
+	if got := len(strings.Split(requests[0].Get("id"), ",")); got != maxPageIDsPerRequest || requests[0].Get("limit") != "250" {
+		t.Errorf("first chunk has %d ids, limit %s", got, requests[0].Get("limit"))
+	}
+	if requests[0].Get("body-format") != "view" {
+		t.Errorf("body-format = %q", requests[0].Get("body-format"))
+	}
+	if len(result.Pages) != 252 || result.Pages[0].ID != "3" || result.Pages[1].ID != "1" {
+		t.Fatalf("pages = %d, first %q %q; want request order", len(result.Pages), result.Pages[0].ID, result.Pages[1].ID)
+	}
+	if strings.Join(result.NotFound, ",") != "2,not-an-id" {
+		t.Errorf("NotFound = %v", result.NotFound)
+	}
+}
+
+func TestFindSpaceIDAndPageID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/wiki/api/v2/spaces":
+			if got := r.URL.Query().Get("keys"); got != "ENG" {
+				t.Errorf("keys = %q, want ENG", got)
+			}
+			_, _ = w.Write([]byte(`{"results":[{"id":"98765","key":"ENG","name":"Engineering"}],"_links":{}}`))
+		case "/wiki/rest/api/search":
+			if got := r.URL.Query().Get("cql"); got != `type=page AND space="ENG" AND title="Say \"hi\""` {
+				t.Errorf("cql = %q", got)
+			}
+			_, _ = w.Write([]byte(`{"results":[]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	if id, err := client.FindSpaceID("ENG"); err != nil || id != "98765" {
+		t.Errorf("FindSpaceID = %q, %v", id, err)
+	}
+	if id, err := client.FindPageID("ENG", `Say "hi"`); err != nil || id != "" {
+		t.Errorf("FindPageID = %q, %v; want no match", id, err)
+	}
+}
+
+func TestGetSpaceByKey(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/wiki/api/v2/spaces":
+			_, _ = w.Write([]byte(`{"results":[{"id":"98765","key":"ENG"}],"_links":{}}`))
+		case "/wiki/api/v2/spaces/98765":
+			if r.URL.Query().Get("description-format") != "plain" || r.URL.Query().Get("include-labels") != "true" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"id":"98765","key":"ENG","name":"Engineering","type":"global","status":"current","homepageId":"111",
+				"description":{"plain":{"value":"Runbooks and designs","representation":"plain"}},
+				"labels":{"results":[{"id":"1","name":"team","prefix":"global"}]}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	space, err := client.GetSpace(GetSpaceOptions{SpaceKey: "ENG"})
+	if err != nil {
+		t.Fatalf("GetSpace: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Errorf("requests = %v, want key lookup then detail", paths)
+	}
+	if space.ID != "98765" || space.HomepageID != "111" || space.Description != "Runbooks and designs" {
+		t.Errorf("space = %+v", space)
+	}
+	if len(space.Labels) != 1 || space.Labels[0] != "team" {
+		t.Errorf("labels = %v", space.Labels)
+	}
+
+	_, err = client.GetSpace(GetSpaceOptions{SpaceKey: "NOPE"})
+	if err == nil {
+		t.Fatal("expected error for unknown key")
+	}
+}
+
+func TestListSpacesFilters(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/wiki/rest/api/user/current":
+			_, _ = w.Write([]byte(`{"accountId":"acc-1","displayName":"Ada"}`))
+		case "/wiki/api/v2/spaces":
+			got = r.URL.Query()
+			_, _ = w.Write([]byte(`{"results":[],"_links":{}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	_, err := client.ListSpaces(ListSpacesOptions{
+		Keys: []string{"ENG", "OPS"}, Type: "global", Status: "current",
+		Labels: []string{"team"}, Favourite: true, Sort: "-name", Limit: 5,
+	})
+	if err != nil {
+		t.Fatalf("ListSpaces: %v", err)
+	}
+	want := map[string]string{"keys": "ENG,OPS", "type": "global", "status": "current", "labels": "team", "favorited-by": "acc-1", "sort": "-name", "limit": "5"}
+	for key, value := range want {
+		if got.Get(key) != value {
+			t.Errorf("%s = %q, want %q", key, got.Get(key), value)
+		}
+	}
+}
+
+func TestListPagesFilters(t *testing.T) {
+	var v2Query, searchQuery, bulkQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/wiki/rest/api/search":
+			searchQuery = r.URL.Query()
+			_, _ = w.Write([]byte(`{"results":[{"content":{"id":"7","type":"page","title":"B"}},{"content":{"id":"5","type":"page","title":"A"}}],
+				"_links":{"next":"/rest/api/search?cursor=next-1"}}`))
+		case r.URL.Path == "/wiki/api/v2/pages" && r.URL.Query().Get("id") != "":
+			bulkQuery = r.URL.Query()
+			_, _ = w.Write([]byte(`{"results":[{"id":"5","title":"A","status":"current","version":{"number":2}},{"id":"7","title":"B","status":"current","version":{"number":9}}],"_links":{}}`))
+		case r.URL.Path == "/wiki/api/v2/pages":
+			v2Query = r.URL.Query()
+			_, _ = w.Write([]byte(`{"results":[],"_links":{}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	if _, err := client.ListPages(ListPagesOptions{SpaceID: "1", Status: "archived", Title: "Runbook"}); err != nil {
+		t.Fatalf("ListPages: %v", err)
+	}
+	if v2Query.Get("status") != "archived" || v2Query.Get("title") != "Runbook" || v2Query.Get("space-id") != "1" {
+		t.Errorf("v2 query = %v", v2Query)
+	}
+	if searchQuery != nil {
+		t.Error("status and title alone should not fall back to CQL")
+	}
+
+	result, err := client.ListPages(ListPagesOptions{
+		SpaceID:       "1",
+		ModifiedAfter: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Sort:          "-modified-date",
+		Limit:         2,
+	})
+	if err != nil {
+		t.Fatalf("ListPages with dates: %v", err)
+	}
+	if got := searchQuery.Get("cql"); got != `type=page AND space.id=1 AND lastmodified >= "2024-06-01" ORDER BY lastmodified desc` {
+		t.Errorf("cql = %q", got)
+	}
+	if bulkQuery.Get("id") != "7,5" {
+		t.Errorf("bulk ids = %q", bulkQuery.Get("id"))
+	}
+	if len(result.Results) != 2 || result.Results[0].ID != "7" || result.Results[0].Version.Number != 9 {
+		t.Errorf("results = %+v, want search order with page details", result.Results)
+	}
+	if result.NextCursor != "next-1" {
+		t.Errorf("NextCursor = %q", result.NextCursor)
+	}
+	if _, err := client.ListPages(ListPagesOptions{Status: "archived", CreatedBefore: time.Now()}); err == nil {
+		t.Error("expected archived status with date filters to be rejected")
+	}
+	if _, err := client.ListPages(ListPagesOptions{SpaceID: "1 OR space.id>0", CreatedBefore: time.Now()}); err == nil || !strings.Contains(err.Error(), "numeric space ID") {
+		t.Errorf("non-numeric space ID err = %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package confluence_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigDefaultsPrecedence_Integration(t *testing.T) {
+	var gotLimit string
+	srv := newBinaryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotLimit = r.URL.Query().Get("limit")
+		writeFixtureResponse(t, w, "spaces_list.json")
+	})
+	defer srv.Close()
+
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+	configDir := filepath.Join(tmp, "config")
+	env := envForIntegration(configDir)
+	creds := []string{"--url", srv.URL, "--email", "user@example.com", "--token", "secret"}
+
+	if stdout, stderr, err := runBinary(binPath, []string{"config", "set", "spaces.list.limit", "3"}, "", env...); err != nil {
+		t.Fatalf("config set failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+	if _, stderr, exitCode, _ := runBinaryWithExitCode(binPath, []string{"config", "set", "format", "yaml"}, "", env...); exitCode != 2 || !strings.Contains(stderr, `"VALIDATION"`) {
+		t.Fatalf("invalid enum: exit=%d stderr=%s", exitCode, stderr)
+	}
+
+	if _, stderr, err := runBinary(binPath, append(creds, "spaces", "list"), "", env...); err != nil {
+		t.Fatalf("spaces list failed: %v\nstderr=%s", err, stderr)
+	}
+	if gotLimit != "3" {
+		t.Fatalf("config default: limit = %q, want %q", gotLimit, "3")
+	}
+
+	if _, stderr, err := runBinary(binPath, append(creds, "spaces", "list", "--limit", "7"), "", env...); err != nil {
+		t.Fatalf("spaces list failed: %v\nstderr=%s", err, stderr)
+	}
+	if gotLimit != "7" {
+		t.Fatalf("flag override: limit = %q, want %q", gotLimit, "7")
+	}
+
+	stdout, stderr, err := runBinary(binPath, []string{"config", "get", "spaces.list.limit"}, "", env...)
+	if err != nil {
+		t.Fatalf("config get failed: %v\nstderr=%s", err, stderr)
+	}
+	var got struct {
+		Item struct {
+			Key    string `json:"key"`
+			Value  string `json:"value"`
+			Source string `json:"source"`
+		} `json:"item"`
+		Schema struct {
+			ItemType string `json:"itemType"`
+		} `json:"schema"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("config get output not valid JSON: %v\nstdout=%s", err, stdout)
+	}
+	if got.Item.Value != "3" || got.Item.Source != "config" || got.Schema.ItemType != "config-value" {
+		t.Fatalf("unexpected config get output: %s", stdout)
+	}
+
+	stdout, _, err = runBinary(binPath, []string{"config", "get", "url"}, "", env...)
+	if err != nil || !strings.Contains(stdout, `"source": "env"`) || !strings.Contains(stdout, `"env": "CONFLUENCE_URL"`) {
+		t.Fatalf("url should come from env: err=%v stdout=%s", err, stdout)
+	}
+}
+
+func TestConfigTOMLProfiles_Integration(t *testing.T) {
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+	configDir := filepath.Join(tmp, "config")
+	if err := os.MkdirAll(configDir, 0o700); err != nil {
+		t.Fatalf("mkdir config dir: %v", err)
+	}
+	toml := "[default]\nformat = \"json\"\n\n[work]\nformat = \"plain\" # agents prefer json\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(toml), 0o600); err != nil {
+		t.Fatalf("write config.toml: %v", err)
+	}
+	env := envForIntegration(configDir)
+
+	stdout, stderr, err := runBinary(binPath, []string{"--profile", "work", "version"}, "", env...)
+	if err != nil {
+		t.Fatalf("version failed: %v\nstderr=%s", err, stderr)
+	}
+	if strings.HasPrefix(stdout, "{") {
+		t.Fatalf("work profile should default to plain output, got %s", stdout)
+	}
+
+	stdout, _, err = runBinary(binPath, []string{"version"}, "", append(env, "CONFLUENCE_PROFILE=work")...)
+	if err != nil || strings.HasPrefix(stdout, "{") {
+		t.Fatalf("CONFLUENCE_PROFILE=work should select plain output: err=%v stdout=%s", err, stdout)
+	}
+
+	if _, _, err := runBinary(binPath, []string{"--profile", "work", "config", "set", "pages.list.limit", "25"}, "", env...); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	body, err := os.ReadFile(filepath.Join(configDir, "config.toml"))
+	if err != nil {
+		t.Fatalf("read config.toml: %v", err)
+	}
+	if !strings.Contains(string(body), "[work.pages.list]\nlimit = 25\n") {
+		t.Fatalf("config.toml not rewritten as TOML:\n%s", body)
+	}
+
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte("[default]\nformat = [\"json\"]\n"), 0o600); err != nil {
+		t.Fatalf("write config.toml: %v", err)
+	}
+	_, stderr, exitCode, _ := runBinaryWithExitCode(binPath, []string{"version"}, "", env...)
+	if exitCode != 2 || !strings.Contains(stderr, `"CONFIG"`) || !strings.Contains(stderr, "line 2") {
+		t.Fatalf("malformed config: exit=%d stderr=%s", exitCode, stderr)
+	}
+}
@@ -0,0 +1,197 @@
+package confluence
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentTypes are the CQL types Search covers. A space result describes the
+// space itself rather than content inside it.
+var ContentTypes = []string{"page", "blogpost", "comment", "attachment", "space"}
+
+type SearchOptions struct {
+	Query     string
+	CQL       string
+	Types     []string // subset of ContentTypes; empty searches all of them
+	SpaceKeys []string
+	Limit     int
+	Cursor    string
+}
+
+const searchExpand = "content.space,content.container"
+
+// Search runs a CQL search over every content type through the v1 search
+// endpoint, which Cloud and Data Center share.
+func (c *Client) Search(opts SearchOptions) (*ListResult[ContentResult], error) {
+	cql, err := buildSearchCQL(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("cql", cql)
+	query.Set("expand", searchExpand)
+	if c.isServer() {
+		if err := serverPaging(query, opts.Limit, opts.Cursor); err != nil {
+			return nil, err
+		}
+	} else {
+		if opts.Limit > 0 {
+			query.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Cursor != "" {
+			query.Set("cursor", opts.Cursor)
+		}
+	}
+
+	body, err := c.doV1("GET", "/search", query)
+	if err != nil {
+		return nil, fmt.Errorf("searching content: %w", err)
+	}
+	var raw struct {
+		Results []searchHit `json:"results"`
+		Start   int         `json:"start"`
+		Links   struct {
+			Next string `json:"next"`
+		} `json:"_links"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("parsing search response: %w", err)
+	}
+
+	results := make([]ContentResult, len(raw.Results))
+	for i, hit := range raw.Results {
+		results[i] = hit.result()
+	}
+	next := extractCursor(raw.Links.Next)
+	if c.isServer() {
+		next = serverPage[searchHit]{Results: raw.Results, Start: raw.Start, Links: raw.Links}.nextCursor()
+	}
+	return &ListResult[ContentResult]{Results: results, NextCursor: next}, nil
+}
+
+// searchHit is one entry of the v1 /search response.
+type searchHit struct {
+	Content *struct {
+		ID        string       `json:"id"`
+		Type      string       `json:"type"`
+		Title     string       `json:"title"`
+		Space     *searchSpace `json:"space,omitempty"`
+		Container *struct {
+			ID    json.Number `json:"id"` // numeric when the container is a space
+			Type  string      `json:"type"`
+			Title string      `json:"title"`
+		} `json:"container,omitempty"`
+	} `json:"content,omitempty"`
+	Space                 *searchSpace `json:"space,omitempty"`
+	Title                 string       `json:"title"`
+	Excerpt               string       `json:"excerpt"`
+	URL                   string       `json:"url"`
+	LastModified          string       `json:"lastModified"`
+	ResultGlobalContainer *struct {
+		DisplayURL string `json:"displayUrl"`
+	} `json:"resultGlobalContainer,omitempty"`
+}
+
+type searchSpace struct {
+	ID   json.Number `json:"id"`
+	Key  string      `json:"key"`
+	Name string      `json:"name"`
+}
+
+func (hit searchHit) result() ContentResult {
+	result := ContentResult{URL: hit.URL}
+	// The top-level title can carry highlight markers too.
+	result.Title, _ = cleanExcerpt(hit.Title)
+	result.Excerpt, result.Highlights = cleanExcerpt(hit.Excerpt)
+	result.LastModified, _ = time.Parse(time.RFC3339, hit.LastModified)
+	switch {
+	case hit.Content != nil:
+		result.ID = hit.Content.ID
+		result.Type = hit.Content.Type
+		if hit.Content.Title != "" {
+			result.Title = hit.Content.Title
+		}
+		if hit.Content.Space != nil {
+			result.SpaceKey = hit.Content.Space.Key
+		}
+		// A page's container is its space; only comments and attachments
+		// point at the content that holds them.
+		if container := hit.Content.Container; container != nil && container.ID != "" && container.Type != "space" {
+			result.Container = &ContentRef{ID: container.ID.String(), Type: container.Type, Title: container.Title}
+		}
+	case hit.Space != nil:
+		result.ID = hit.Space.ID.String()
+		result.Type = "space"
+		result.SpaceKey = hit.Space.Key
+	}
+	if result.SpaceKey == "" && hit.ResultGlobalContainer != nil {
+		result.SpaceKey = spaceKeyFromURL(hit.ResultGlobalContainer.DisplayURL)
+	}
+	return result
+}
+
+// spaceKeyFromURL reads the key from a /spaces/KEY (Cloud) or /display/KEY
+// (Data Center) space link.
+func spaceKeyFromURL(link string) string {
+	parts := strings.Split(strings.Trim(link, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "spaces" || parts[i] == "display" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+func buildSearchCQL(opts SearchOptions) (string, error) {
+	rawCQL := strings.TrimSpace(opts.CQL)
+	query := strings.TrimSpace(opts.Query)
+	if rawCQL != "" {
+		if query != "" {
+			return "", fmt.Errorf("provide exactly one of search query or cql")
+		}
+		if len(opts.Types) > 0 || len(opts.SpaceKeys) > 0 {
+			return "", fmt.Errorf("type and space-key cannot be combined with raw cql")
+		}
+		return rawCQL, nil
+	}
+	if query == "" {
+		return "", fmt.Errorf("search query or cql is required")
+	}
+
+	var clauses []string
+	for _, contentType := range opts.Types {
+		if !isContentType(contentType) {
+			return "", fmt.Errorf("unsupported type %q; use %s", contentType, strings.Join(ContentTypes, ", "))
+		}
+	}
+	switch len(opts.Types) {
+	case 0:
+	case 1:
+		clauses = append(clauses, "type="+opts.Types[0])
+	default:
+		clauses = append(clauses, "type in ("+strings.Join(opts.Types, ",")+")")
+	}
+	clauses = append(clauses, fmt.Sprintf("text ~ \"%s\"", escapeCQL(query)))
+	switch len(opts.SpaceKeys) {
+	case 0:
+	case 1:
+		clauses = append(clauses, fmt.Sprintf("space=\"%s\"", escapeCQL(opts.SpaceKeys[0])))
+	default:
+		clauses = append(clauses, "space in ("+quoteCQLList(opts.SpaceKeys)+")")
+	}
+	return strings.Join(clauses, " AND "), nil
+}
+
+func isContentType(value string) bool {
+	for _, contentType := range ContentTypes {
+		if value == contentType {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package confluence_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"path/filepath"
@@ -21,13 +22,30 @@ func TestHelpGolden_Integration(t *testing.T) {
 		{name: "root", args: []string{"--help"}, golden: "help/root.txt"},
 		{name: "spaces", args: []string{"spaces", "--help"}, golden: "help/spaces.txt"},
 		{name: "spaces_list", args: []string{"spaces", "list", "--help"}, golden: "help/spaces_list.txt"},
+		{name: "spaces_get", args: []string{"spaces", "get", "--help"}, golden: "help/spaces_get.txt"},
 		{name: "pages", args: []string{"pages", "--help"}, golden: "help/pages.txt"},
 		{name: "pages_list", args: []string{"pages", "list", "--help"}, golden: "help/pages_list.txt"},
 		{name: "pages_get", args: []string{"pages", "get", "--help"}, golden: "help/pages_get.txt"},
+		{name: "pages_get_many", args: []string{"pages", "get-many", "--help"}, golden: "help/pages_get_many.txt"},
 		{name: "pages_tree", args: []string{"pages", "tree", "--help"}, golden: "help/pages_tree.txt"},
+		{name: "pages_ancestors", args: []string{"pages", "ancestors", "--help"}, golden: "help/pages_ancestors.txt"},
+		{name: "pages_descendants", args: []string{"pages", "descendants", "--help"}, golden: "help/pages_descendants.txt"},
 		{name: "pages_search", args: []string{"pages", "search", "--help"}, golden: "help/pages_search.txt"},
+		{name: "search", args: []string{"search", "--help"}, golden: "help/search.txt"},
+		{name: "cql", args: []string{"cql", "--help"}, golden: "help/cql.txt"},
+		{name: "cql_lint", args: []string{"cql", "lint", "--help"}, golden: "help/cql_lint.txt"},
 		{name: "auth", args: []string{"auth", "--help"}, golden: "help/auth.txt"},
 		{name: "auth_login", args: []string{"auth", "login", "--help"}, golden: "help/auth_login.txt"},
+		{name: "config", args: []string{"config", "--help"}, golden: "help/config.txt"},
+		{name: "config_get", args: []string{"config", "get", "--help"}, golden: "help/config_get.txt"},
+		{name: "config_set", args: []string{"config", "set", "--help"}, golden: "help/config_set.txt"},
+		{name: "config_list", args: []string{"config", "list", "--help"}, golden: "help/config_list.txt"},
+		{name: "batch", args: []string{"batch", "--help"}, golden: "help/batch.txt"},
+		{name: "mcp", args: []string{"mcp", "--help"}, golden: "help/mcp.txt"},
+		{name: "mcp_serve", args: []string{"mcp", "serve", "--help"}, golden: "help/mcp_serve.txt"},
+		{name: "serve", args: []string{"serve", "--help"}, golden: "help/serve.txt"},
+		{name: "schema", args: []string{"schema", "--help"}, golden: "help/schema.txt"},
+		{name: "help", args: []string{"help", "--help"}, golden: "help/help.txt"},
 		{name: "version", args: []string{"version", "--help"}, golden: "help/version.txt"},
 	}
 
@@ -179,6 +197,61 @@ func TestSpacesListPlainContract_Integration(t *testing.T) {
 	}
 }
 
+func TestSpacesListNDJSONContract_Integration(t *testing.T) {
+	srv := newBinaryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeFixtureResponse(t, w, "spaces_list.json")
+	})
+	defer srv.Close()
+
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+
+	stdout, stderr, err := runBinary(binPath, []string{
+		"--url", srv.URL, "--email", "a@b.com", "--token", "tok",
+		"--format", "ndjson", "--fields", "key", "spaces", "list",
+	}, "", envForIntegration(filepath.Join(tmp, "config"))...)
+	if err != nil {
+		t.Fatalf("spaces list --format ndjson failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected result lines plus trailer, got:\n%s", stdout)
+	}
+	if lines[0] != `{"key":"CF"}` {
+		t.Fatalf("first line = %s, want compact projected result", lines[0])
+	}
+	trailer := lines[len(lines)-1]
+	if trailer != `{"page":{"limit":10,"nextCursor":"eyJpZCI6MzIwOTc5MzMwNX0="},"schema":{"itemType":"space-summary","fields":["key"]}}` {
+		t.Fatalf("unexpected trailer: %s", trailer)
+	}
+}
+
+func TestSpacesListCSVContract_Integration(t *testing.T) {
+	srv := newBinaryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeFixtureResponse(t, w, "spaces_list.json")
+	})
+	defer srv.Close()
+
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+
+	stdout, stderr, err := runBinary(binPath, []string{
+		"--url", srv.URL, "--email", "a@b.com", "--token", "tok",
+		"--format", "csv", "spaces", "list",
+	}, "", envForIntegration(filepath.Join(tmp, "config"))...)
+	if err != nil {
+		t.Fatalf("spaces list --format csv failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+	lines := strings.Split(stdout, "\n")
+	if lines[0] != "id,key,name,type,status" {
+		t.Fatalf("header = %q, want schema fields", lines[0])
+	}
+	if !strings.Contains(stdout, ",CF,BeCSEE Cloud Foundation,") {
+		t.Fatalf("expected CF row, got:\n%s", stdout)
+	}
+}
+
 func TestPagesListJSONContract_Integration(t *testing.T) {
 	srv := newBinaryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/wiki/api/v2/pages" {
@@ -290,6 +363,36 @@ func TestPagesGetDefaultMetadataOnly_Integration(t *testing.T) {
 	}
 }
 
+func TestPagesGetFieldsProjection_Integration(t *testing.T) {
+	srv := newBinaryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, []byte(`{"id":"123","title":"Overview","spaceId":"S1","status":"current","version":{"number":7,"message":"edit"}}`))
+	})
+	defer srv.Close()
+
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+	env := envForIntegration(filepath.Join(tmp, "config"))
+	creds := []string{"--url", srv.URL, "--email", "a@b.com", "--token", "tok"}
+
+	stdout, stderr, err := runBinary(binPath, append(creds, "--fields", "title,version.number", "pages", "get", "--page-id", "123"), "", env...)
+	if err != nil {
+		t.Fatalf("pages get failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+	want := `{"item":{"title":"Overview","version":{"number":7}},"schema":{"itemType":"page-detail","fields":["title","version.number"]}}`
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(stdout)); err != nil {
+		t.Fatalf("pages get output invalid JSON: %v\nstdout=%s", err, stdout)
+	}
+	if compact.String() != want {
+		t.Fatalf("projection = %s, want %s", compact.String(), want)
+	}
+
+	_, stderr, exitCode, _ := runBinaryWithExitCode(binPath, append(creds, "--fields", "title,versionNumber", "pages", "get", "--page-id", "123"), "", env...)
+	if exitCode != 2 || !strings.Contains(stderr, `"VALIDATION"`) || !strings.Contains(stderr, "version.number") {
+		t.Fatalf("unknown field: exit=%d stderr=%s", exitCode, stderr)
+	}
+}
+
 func TestPagesGetBodyFormatView_Integration(t *testing.T) {
 	pageJSON := `{"id":"123","title":"Overview","spaceId":"S1","status":"current","version":{"number":7},"body":{"view":{"representation":"view","value":"<h2>Overview</h2><p>Status: <strong>LIVE</strong></p>"}}}`
 
@@ -451,3 +554,33 @@ func TestPagesTreeContracts_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestPagesGetManyReportsNotFound_Integration(t *testing.T) {
+	srv := newBinaryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("id"); got != "123,404" {
+			t.Errorf("id filter = %q, want 123,404", got)
+		}
+		writeJSONResponse(w, []byte(`{"results":[{"id":"123","title":"Overview","spaceId":"S1","status":"current","version":{"number":7}}],"_links":{}}`))
+	})
+	defer srv.Close()
+
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+	env := envForIntegration(filepath.Join(tmp, "config"))
+
+	stdout, stderr, err := runBinary(binPath, []string{
+		"--url", srv.URL, "--email", "a@b.com", "--token", "tok",
+		"--fields", "id,title", "pages", "get-many",
+	}, "123\n404\n123\n", env...)
+	if err != nil {
+		t.Fatalf("pages get-many failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+	want := `{"results":[{"id":"123","title":"Overview"}],"page":{"limit":2},"notFound":["404"],"schema":{"itemType":"page-detail","fields":["id","title"]}}`
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(stdout)); err != nil {
+		t.Fatalf("invalid JSON: %v\nstdout=%s", err, stdout)
+	}
+	if compact.String() != want {
+		t.Fatalf("get-many = %s, want %s", compact.String(), want)
+	}
+}
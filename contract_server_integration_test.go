@@ -0,0 +1,81 @@
+package confluence_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServerFlavorPagesListContract_Integration(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer pat-token" {
+			t.Errorf("Authorization = %q, want bearer PAT", got)
+			http.Error(w, "bad auth", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/confluence/rest/api/content/search" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/confluence/rest/api/content/search")
+			http.Error(w, "bad path", http.StatusBadRequest)
+			return
+		}
+		if got := r.URL.Query().Get("cql"); got != "type=page AND space.id=98305" {
+			t.Errorf("cql = %q", got)
+		}
+		writeFixtureResponse(t, w, "server_content_search.json")
+	}))
+	defer srv.Close()
+
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+	env := append(envForIntegration(filepath.Join(tmp, "config")), "CONFLUENCE_FLAVOR=server")
+
+	stdout, stderr, err := runBinary(binPath, []string{
+		"--url", srv.URL + "/confluence", "--token", "pat-token",
+		"pages", "list", "--space-id", "98305",
+	}, "", env...)
+	if err != nil {
+		t.Fatalf("pages list failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+	if strings.TrimSpace(stderr) != "" {
+		t.Fatalf("expected empty stderr, got %s", stderr)
+	}
+
+	var result struct {
+		Results []struct {
+			ID            string `json:"id"`
+			SpaceID       string `json:"spaceId"`
+			ParentID      string `json:"parentId"`
+			VersionNumber int    `json:"versionNumber"`
+		} `json:"results"`
+		Page struct {
+			Limit      int    `json:"limit"`
+			NextCursor string `json:"nextCursor"`
+		} `json:"page"`
+		Schema struct {
+			ItemType string `json:"itemType"`
+		} `json:"schema"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("pages list output not valid JSON: %v\nstdout=%s", err, stdout)
+	}
+	if len(result.Results) != 2 || result.Results[0].ID != "65601" || result.Results[0].SpaceID != "98305" {
+		t.Fatalf("unexpected results: %s", stdout)
+	}
+	if result.Results[0].ParentID != "65590" || result.Results[0].VersionNumber != 12 {
+		t.Fatalf("unexpected first result: %s", stdout)
+	}
+	if result.Page.NextCursor != "2" {
+		t.Fatalf("page.nextCursor = %q, want %q", result.Page.NextCursor, "2")
+	}
+	if result.Schema.ItemType != "page-summary" {
+		t.Fatalf("schema.itemType = %q, want %q", result.Schema.ItemType, "page-summary")
+	}
+
+	_, stderr, exitCode, _ := runBinaryWithExitCode(binPath, []string{"--url", srv.URL, "spaces", "list"}, "", env...)
+	if exitCode != 2 || !strings.Contains(stderr, "personal access token") {
+		t.Fatalf("missing PAT: exit=%d stderr=%s", exitCode, stderr)
+	}
+}
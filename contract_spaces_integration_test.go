@@ -0,0 +1,84 @@
+package confluence_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSpacesGetAndPagesListBySpaceKey_Integration(t *testing.T) {
+	srv := newBinaryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/api/v2/spaces":
+			if got := r.URL.Query().Get("keys"); got != "ENG" {
+				writeJSONResponse(w, []byte(`{"results":[],"_links":{}}`))
+				return
+			}
+			writeJSONResponse(w, []byte(`{"results":[{"id":"98765","key":"ENG","name":"Engineering"}],"_links":{}}`))
+		case "/wiki/api/v2/spaces/98765":
+			writeJSONResponse(w, []byte(`{"id":"98765","key":"ENG","name":"Engineering","type":"global","status":"current","homepageId":"111",
+				"description":{"plain":{"value":"Runbooks"}},"labels":{"results":[{"name":"team"}]}}`))
+		case "/wiki/api/v2/pages":
+			if got := r.URL.Query().Get("space-id"); got != "98765" {
+				t.Errorf("space-id = %q, want resolved ID", got)
+			}
+			writeFixtureResponse(t, w, "pages_list.json")
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	defer srv.Close()
+
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+	env := envForIntegration(filepath.Join(tmp, "config"))
+	creds := []string{"--url", srv.URL, "--email", "a@b.com", "--token", "tok"}
+
+	stdout, stderr, err := runBinary(binPath, append(creds, "spaces", "get", "--space-key", "ENG"), "", env...)
+	if err != nil {
+		t.Fatalf("spaces get failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+	var space struct {
+		Item struct {
+			ID          string   `json:"id"`
+			Description string   `json:"description"`
+			HomepageID  string   `json:"homepageId"`
+			Labels      []string `json:"labels"`
+		} `json:"item"`
+		Schema struct {
+			ItemType string `json:"itemType"`
+		} `json:"schema"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &space); err != nil {
+		t.Fatalf("spaces get output not valid JSON: %v\nstdout=%s", err, stdout)
+	}
+	if space.Item.ID != "98765" || space.Item.Description != "Runbooks" || space.Item.HomepageID != "111" || len(space.Item.Labels) != 1 {
+		t.Fatalf("unexpected space: %s", stdout)
+	}
+	if space.Schema.ItemType != "space-detail" {
+		t.Fatalf("schema.itemType = %q, want space-detail", space.Schema.ItemType)
+	}
+	if strings.Contains(stdout, `"createdAt": "`) {
+		t.Fatalf("space without createdAt serialised one: %s", stdout)
+	}
+
+	stdout, stderr, err = runBinary(binPath, append(creds, "pages", "list", "--space-key", "ENG"), "", env...)
+	if err != nil {
+		t.Fatalf("pages list --space-key failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+	if !strings.Contains(stdout, `"flag": "space-key"`) || !strings.Contains(stdout, `"id": "98765"`) {
+		t.Fatalf("pages list output does not echo the resolved space: %s", stdout)
+	}
+
+	_, stderr, exitCode, _ := runBinaryWithExitCode(binPath, append(creds, "pages", "list", "--space-key", "NOPE"), "", env...)
+	if exitCode != 2 || !strings.Contains(stderr, "no space with key NOPE") {
+		t.Fatalf("unknown key: exit=%d stderr=%s", exitCode, stderr)
+	}
+	_, stderr, exitCode, _ = runBinaryWithExitCode(binPath, append(creds, "spaces", "get"), "", env...)
+	if exitCode != 2 || !strings.Contains(stderr, "exactly one of --space-id or --space-key") {
+		t.Fatalf("missing selector: exit=%d stderr=%s", exitCode, stderr)
+	}
+}
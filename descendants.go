@@ -0,0 +1,55 @@
+package confluence
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MaxDescendantDepth is the deepest level the v2 descendants endpoint returns.
+const MaxDescendantDepth = 5
+
+type GetPageDescendantsOptions struct {
+	PageID string
+	Depth  int // levels below the page, 1 to MaxDescendantDepth; 0 sends no limit
+	Limit  int
+	Cursor string
+}
+
+// GetPageDescendants lists everything below a page in one flat, cursor
+// paginated listing instead of one children request per node.
+func (c *Client) GetPageDescendants(opts GetPageDescendantsOptions) (*ListResult[Descendant], error) {
+	if c.isServer() {
+		return c.getPageDescendantsServer(opts)
+	}
+
+	query := url.Values{}
+	if opts.Depth > 0 {
+		query.Set("depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+
+	body, err := c.do("GET", "/pages/"+opts.PageID+"/descendants", query)
+	if err != nil {
+		return nil, fmt.Errorf("getting page descendants: %w", err)
+	}
+
+	var raw paginatedResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("parsing descendants response: %w", err)
+	}
+	var descendants []Descendant
+	if err := json.Unmarshal(raw.Results, &descendants); err != nil {
+		return nil, fmt.Errorf("parsing descendants: %w", err)
+	}
+	return &ListResult[Descendant]{
+		Results:    descendants,
+		NextCursor: extractCursor(raw.Links.Next),
+	}, nil
+}
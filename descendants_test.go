@@ -0,0 +1,72 @@
+package confluence
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGetPageDescendants(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/api/v2/pages/400/descendants" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"401","type":"page","title":"Context","status":"current","parentId":"400","depth":1,"childPosition":0},
+			{"id":"402","type":"folder","title":"Drafts","status":"current","parentId":"401","depth":2}
+		],"_links":{"next":"/wiki/api/v2/pages/400/descendants?cursor=d-2"}}`))
+	}))
+	defer srv.Close()
+
+	result, err := newTestClient(srv.URL).GetPageDescendants(GetPageDescendantsOptions{PageID: "400", Depth: 2, Limit: 2, Cursor: "d-1"})
+	if err != nil {
+		t.Fatalf("GetPageDescendants: %v", err)
+	}
+	if got.Get("depth") != "2" || got.Get("limit") != "2" || got.Get("cursor") != "d-1" {
+		t.Errorf("query = %v", got)
+	}
+	want := Descendant{ID: "402", Type: "folder", Title: "Drafts", Status: "current", ParentID: "401", Depth: 2}
+	if len(result.Results) != 2 || result.Results[1] != want || result.NextCursor != "d-2" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestServerGetPageDescendants(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/confluence/rest/api/content/100/descendant/page" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"101","type":"page","status":"current","title":"Child","ancestors":[{"id":"1"},{"id":"100"}]},
+			{"id":"102","type":"page","status":"current","title":"Grandchild","ancestors":[{"id":"1"},{"id":"100"},{"id":"101"}]}
+		],"start":0,"limit":2,"size":2,"_links":{"next":"/rest/api/content/100/descendant/page?start=2"}}`))
+	}))
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	result, err := client.GetPageDescendants(GetPageDescendantsOptions{PageID: "100", Limit: 2})
+	if err != nil {
+		t.Fatalf("GetPageDescendants: %v", err)
+	}
+	if got.Get("expand") != "ancestors" || got.Get("limit") != "2" {
+		t.Errorf("query = %v", got)
+	}
+	if len(result.Results) != 2 || result.NextCursor != "2" {
+		t.Fatalf("result = %+v", result)
+	}
+	if child, grandchild := result.Results[0], result.Results[1]; child.Depth != 1 || child.ParentID != "100" || grandchild.Depth != 2 || grandchild.ParentID != "101" {
+		t.Errorf("depths = %+v %+v", child, grandchild)
+	}
+
+	result, err = client.GetPageDescendants(GetPageDescendantsOptions{PageID: "100", Depth: 1, Limit: 2})
+	if err != nil || len(result.Results) != 1 || result.NextCursor != "2" {
+		t.Errorf("depth 1 = %+v, %v", result, err)
+	}
+}
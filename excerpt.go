@@ -0,0 +1,71 @@
+package confluence
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightStart = "@@@hl@@@"
+	highlightEnd   = "@@@endhl@@@"
+)
+
+// cleanExcerpt turns a v1 search excerpt into plain text: highlight markers
+// are removed, HTML entities decoded, and whitespace collapsed. The marked
+// spans are returned as character offsets into the cleaned text.
+func cleanExcerpt(raw string) (string, []Highlight) {
+	var (
+		text       strings.Builder
+		highlights []Highlight
+		length     int
+		pendingGap bool
+		opening    bool // a span starts at the next character
+		open       = -1
+	)
+	for raw != "" {
+		segment, marker := raw, ""
+		if i := strings.Index(raw, "@@@"); i >= 0 {
+			switch {
+			case strings.HasPrefix(raw[i:], highlightStart):
+				segment, marker = raw[:i], highlightStart
+			case strings.HasPrefix(raw[i:], highlightEnd):
+				segment, marker = raw[:i], highlightEnd
+			default:
+				segment = raw[:i+3]
+			}
+		}
+		raw = raw[len(segment)+len(marker):]
+
+		for _, r := range html.UnescapeString(segment) {
+			if unicode.IsSpace(r) {
+				pendingGap = length > 0
+				continue
+			}
+			if pendingGap {
+				text.WriteByte(' ')
+				length++
+				pendingGap = false
+			}
+			if opening {
+				open, opening = length, false
+			}
+			text.WriteRune(r)
+			length++
+		}
+
+		switch {
+		case marker == highlightStart && open < 0:
+			opening = true
+		case marker == highlightEnd:
+			if open >= 0 {
+				highlights = append(highlights, Highlight{Start: open, End: length})
+			}
+			open, opening = -1, false
+		}
+	}
+	if open >= 0 {
+		highlights = append(highlights, Highlight{Start: open, End: length})
+	}
+	return text.String(), highlights
+}
@@ -6,6 +6,7 @@ require (
 	github.com/JohannesKaufmann/html-to-markdown v1.6.0
 	github.com/PuerkitoBio/goquery v1.9.2
 	github.com/alecthomas/kong v1.13.0
+	github.com/jmespath/go-jmespath v0.4.0
 	golang.org/x/term v0.28.0
 )
 
@@ -7,14 +7,15 @@ import (
 	"os"
 	"strings"
 
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
 	"golang.org/x/term"
 )
 
 var isTerminal = term.IsTerminal
 
 type AuthLoginCmd struct {
-	StdinJSON  bool `name:"stdin-json" help:"Read {url,email,token} JSON from piped stdin"`
-	TokenStdin bool `name:"token-stdin" help:"Read token from piped stdin"`
+	StdinJSON  bool `name:"stdin-json" help:"Read {url,email,token} or {url,oauth:{...}} JSON from piped stdin" exclusive:"input"`
+	TokenStdin bool `name:"token-stdin" help:"Read token from piped stdin" exclusive:"input"`
 }
 
 func (cmd *AuthLoginCmd) Run(app *App) error {
@@ -24,9 +25,10 @@ func (cmd *AuthLoginCmd) Run(app *App) error {
 
 	stdinIsTerminal := isTerminal(int(os.Stdin.Fd()))
 	creds := Credentials{
-		URL:   strings.TrimSpace(app.URL),
-		Email: strings.TrimSpace(app.Email),
-		Token: strings.TrimSpace(app.Token),
+		URL:    strings.TrimSpace(app.URL),
+		Email:  strings.TrimSpace(app.Email),
+		Token:  strings.TrimSpace(app.Token),
+		Flavor: app.Flavor,
 	}
 
 	switch {
@@ -41,12 +43,19 @@ func (cmd *AuthLoginCmd) Run(app *App) error {
 		if err != nil {
 			return validationErrorf(helpHint("auth login"), "read stdin credentials: %v", err)
 		}
+		if stdinCreds.Flavor == "" {
+			stdinCreds.Flavor = creds.Flavor
+		}
 		creds = stdinCreds
 	case cmd.TokenStdin:
 		if creds.Token != "" {
 			return validationError("--token-stdin cannot be combined with --token", helpHint("auth login"))
 		}
-		if creds.URL == "" || creds.Email == "" {
+		if creds.flavor() == confluence.FlavorServer {
+			if creds.URL == "" {
+				return validationError("--token-stdin requires --url (or matching env var) for --flavor server", helpHint("auth login"))
+			}
+		} else if creds.URL == "" || creds.Email == "" {
 			return validationError("--token-stdin requires --url and --email (or matching env vars)", helpHint("auth login"))
 		}
 		if stdinIsTerminal {
@@ -64,7 +73,17 @@ func (cmd *AuthLoginCmd) Run(app *App) error {
 	creds.URL = strings.TrimSpace(creds.URL)
 	creds.Email = strings.TrimSpace(creds.Email)
 	creds.Token = strings.TrimSpace(creds.Token)
-	if creds.URL == "" || creds.Email == "" || creds.Token == "" {
+	switch {
+	case creds.Flavor != "" && creds.Flavor != confluence.FlavorCloud && creds.Flavor != confluence.FlavorServer:
+		return validationErrorf(helpHint("auth login"), "flavor must be one of: cloud, server; got %q", creds.Flavor)
+	case creds.OAuth != nil:
+		creds.OAuth.trim()
+		if err := validateOAuthCredentials(creds); err != nil {
+			return err
+		}
+	case creds.flavor() == confluence.FlavorServer && !creds.complete():
+		return validationError("auth login with --flavor server requires url and token (personal access token); use flags/env vars, --stdin-json, or --token-stdin", helpHint("auth login"))
+	case !creds.complete():
 		return validationError("auth login requires url, email, and token; use flags/env vars, --stdin-json, or --token-stdin", helpHint("auth login"))
 	}
 
@@ -78,7 +97,7 @@ func (cmd *AuthLoginCmd) Run(app *App) error {
 		discardWrite(fmt.Fprintf(app.Stdout, "Stored credentials in %s\n", storedIn))
 		return nil
 	}
-	return renderJSON(app.Stdout, response)
+	return app.renderEnvelope(response)
 }
 
 func readCredentialsJSON(r io.Reader) (Credentials, error) {
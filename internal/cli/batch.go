@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/kong"
+)
+
+const maxBatchConcurrency = 16
+
+type BatchCmd struct {
+	Concurrency int `help:"Maximum commands running at once" default:"4"`
+}
+
+// batchRequest is one JSONL input line.
+type batchRequest struct {
+	Cmd  string                     `json:"cmd"`
+	Args map[string]json.RawMessage `json:"args"`
+}
+
+// BatchResult is one JSONL output line: the input line index with either the
+// command's envelope or its error detail.
+type BatchResult struct {
+	Index  int             `json:"index"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *ErrorDetail    `json:"error,omitempty"`
+}
+
+func (cmd *BatchCmd) Run(app *App) error {
+	if err := validateRange("concurrency", cmd.Concurrency, 1, maxBatchConcurrency, helpHint("batch")); err != nil {
+		return err
+	}
+	if isTerminal(int(os.Stdin.Fd())) {
+		return validationError("batch reads JSONL commands from piped stdin", helpHint("batch"))
+	}
+	return app.runBatch(os.Stdin, cmd.Concurrency)
+}
+
+// runBatch executes each input line with at most concurrency commands in
+// flight and writes results as they complete.
+func (app *App) runBatch(r io.Reader, concurrency int) error {
+	commands, err := app.batchCommands()
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		slots = make(chan struct{}, concurrency)
+	)
+	emit := func(result BatchResult) {
+		b, _ := json.Marshal(result)
+		mu.Lock()
+		defer mu.Unlock()
+		discardWrite(fmt.Fprintln(app.Stdout, string(b)))
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for index := 0; scanner.Scan(); index++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		args, err := batchArgs(line, commands)
+		if err != nil {
+			emit(BatchResult{Index: index, Error: &ErrorDetail{Code: "VALIDATION", Message: err.Error(), Hint: helpHint("batch")}})
+			continue
+		}
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(index int, args []string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			emit(app.runBatchLine(index, args))
+		}(index, args)
+	}
+	wg.Wait()
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read batch input: %w", err)
+	}
+	return nil
+}
+
+// batchCommands maps each read command to its flags by camelCase name. The
+// commands are the read set from commandContracts, the same ones serve and
+// MCP expose.
+func (app *App) batchCommands() (map[string]map[string]*kong.Flag, error) {
+	commands := map[string]map[string]*kong.Flag{}
+	for _, command := range readCommands() {
+		_, flags, err := commandFlags(app.kongCtx.Model, command)
+		if err != nil {
+			return nil, err
+		}
+		byName := map[string]*kong.Flag{}
+		for _, flag := range flags {
+			byName[camelFlagName(flag.Name)] = flag
+		}
+		commands[command] = byName
+	}
+	return commands, nil
+}
+
+func (app *App) runBatchLine(index int, args []string) BatchResult {
+	var stdout bytes.Buffer
+	if detail, code := app.runInProcess(args, &stdout); code != ExitOK {
+		return BatchResult{Index: index, Error: &detail}
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, stdout.Bytes()); err != nil {
+		return BatchResult{Index: index, Error: &ErrorDetail{Code: "INTERNAL", Message: err.Error(), Hint: "Report the command output as a bug."}}
+	}
+	return BatchResult{Index: index, Result: compact.Bytes()}
+}
+
+// batchArgs parses one input line into the command line it runs.
+func batchArgs(line []byte, commands map[string]map[string]*kong.Flag) ([]string, error) {
+	var request batchRequest
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		return nil, fmt.Errorf(`invalid batch line: %v; expected {"cmd":"pages get","args":{...}}`, err)
+	}
+	command := strings.Join(strings.Fields(request.Cmd), " ")
+	flags, ok := commands[command]
+	if !ok {
+		return nil, fmt.Errorf("unsupported cmd %q; valid: %s", request.Cmd, strings.Join(readCommands(), ", "))
+	}
+
+	names := make([]string, 0, len(request.Args))
+	for name := range request.Args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := strings.Fields(command)
+	for _, name := range names {
+		flag, ok := flags[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument %q for %s", name, command)
+		}
+		values, err := flagArgValues(flag, request.Args[name])
+		if err != nil {
+			return nil, fmt.Errorf("argument %q: %w", name, err)
+		}
+		for _, value := range values {
+			args = append(args, "--"+flag.Name+"="+value)
+		}
+	}
+	return append(args, "--format=json"), nil
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchRunsLinesWithBoundedConcurrency(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if strings.HasSuffix(r.URL.Path, "/pages/999") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"No page found"}]}`))
+			return
+		}
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"` + id + `","title":"Page ` + id + `","spaceId":"S1","status":"current","version":{"number":1}}`))
+	})
+	var stdout bytes.Buffer
+	app.Stdout = &stdout
+
+	input := strings.Join([]string{
+		`{"cmd":"pages get","args":{"pageId":"1","fields":["id"]}}`,
+		`{"cmd":"pages get","args":{"pageId":"2","fields":["id"]}}`,
+		``,
+		`{"cmd":"pages get","args":{"pageId":"999"}}`,
+		`{"cmd":"pages delete","args":{}}`,
+		`{"cmd":"pages get","args":{"pageId":3}}`,
+		`not json`,
+		`{"cmd":"pages get","args":{"pageId":"4","fields":["id"]}}`,
+		`{"cmd":"pages get","args":{"pageId":"5","fields":["id"]}}`,
+	}, "\n")
+	if err := app.runBatch(strings.NewReader(input), 2); err != nil {
+		t.Fatalf("runBatch: %v", err)
+	}
+
+	var results []BatchResult
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		var result BatchResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Fatalf("parse %q: %v", line, err)
+		}
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+
+	var got []string
+	for _, result := range results {
+		switch {
+		case result.Error != nil:
+			got = append(got, result.Error.Code)
+		default:
+			got = append(got, string(result.Result))
+		}
+	}
+	want := []string{
+		`{"item":{"id":"1"},"schema":{"itemType":"page-detail","fields":["id"]}}`,
+		`{"item":{"id":"2"},"schema":{"itemType":"page-detail","fields":["id"]}}`,
+		"API_ERROR",
+		"VALIDATION",
+		"VALIDATION",
+		"VALIDATION",
+		`{"item":{"id":"4"},"schema":{"itemType":"page-detail","fields":["id"]}}`,
+		`{"item":{"id":"5"},"schema":{"itemType":"page-detail","fields":["id"]}}`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("batch results:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if indexes := []int{results[0].Index, results[2].Index, results[7].Index}; indexes[0] != 0 || indexes[1] != 3 || indexes[2] != 8 {
+		t.Errorf("indexes = %v, want blank lines counted", indexes)
+	}
+	if peak := maxInFlight.Load(); peak > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", peak)
+	}
+}
+
+// notReadCommands are the leaf commands batch, MCP, and serve deliberately
+// leave out: they write local state, read stdin, or run a server.
+var notReadCommands = map[string]bool{
+	"cql lint": true, "auth login": true, "config get": true, "config set": true, "config list": true,
+	"batch": true, "mcp serve": true, "serve": true, "help": true, "schema": true,
+}
+
+func TestBatchAcceptsEveryReadCommand(t *testing.T) {
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {})
+	commands, err := app.batchCommands()
+	if err != nil {
+		t.Fatalf("batchCommands: %v", err)
+	}
+	for _, leaf := range app.kongCtx.Model.Leaves(true) {
+		command := leaf.Path()
+		if notReadCommands[command] {
+			continue
+		}
+		if _, err := batchArgs([]byte(`{"cmd":"`+command+`","args":{}}`), commands); err != nil {
+			t.Errorf("%s is not a batch command; give its commandContracts entry a Route or list it in notReadCommands: %v", command, err)
+		}
+	}
+}
+
+func TestBatchRejectsConcurrencyOutOfRange(t *testing.T) {
+	t.Setenv("CONFLUENCE_CONFIG_DIR", t.TempDir())
+	_, stderr, code := runCLIForTest(t, []string{"--url", "https://example.atlassian.net", "--email", "a@b.com", "--token", "tok", "batch", "--concurrency", "0"}, false)
+	if code != ExitValidation || !strings.Contains(stderr, "concurrency must be between 1 and 16") {
+		t.Fatalf("exit=%d stderr=%s", code, stderr)
+	}
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+)
+
+// CommandCatalog is the machine-readable command tree emitted by
+// `confluence help`, built from the kong model.
+type CommandCatalog struct {
+	Name        string        `json:"name"`
+	Version     string        `json:"version"`
+	GlobalFlags []FlagSpec    `json:"globalFlags"`
+	Commands    []CommandSpec `json:"commands"`
+}
+
+// CommandSpec describes one runnable command.
+type CommandSpec struct {
+	Name            string         `json:"name"`
+	Help            string         `json:"help"`
+	Arguments       []ArgumentSpec `json:"arguments,omitempty"`
+	Flags           []FlagSpec     `json:"flags"`
+	ExclusiveGroups [][]string     `json:"exclusiveGroups,omitempty"`
+	Output          *OutputSpec    `json:"output,omitempty"`
+	Examples        []string       `json:"examples,omitempty"`
+}
+
+// ArgumentSpec describes a positional argument.
+type ArgumentSpec struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Help     string `json:"help"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// FlagSpec describes a flag with its type, default, and allowed values.
+type FlagSpec struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Help     string   `json:"help"`
+	Default  string   `json:"default,omitempty"`
+	Enum     []string `json:"enum,omitempty"`
+	Required bool     `json:"required,omitempty"`
+	Env      []string `json:"env,omitempty"`
+}
+
+// OutputSpec links a command to its success envelope contract.
+type OutputSpec struct {
+	Envelope string `json:"envelope"`
+	ItemType string `json:"itemType"`
+	Schema   string `json:"schema"`
+}
+
+func buildCommandCatalog(model *kong.Application, version string) CommandCatalog {
+	catalog := CommandCatalog{
+		Name:        model.Name,
+		Version:     version,
+		GlobalFlags: flagSpecs(model.Flags),
+	}
+	for _, leaf := range model.Leaves(true) {
+		catalog.Commands = append(catalog.Commands, commandSpec(leaf))
+	}
+	return catalog
+}
+
+func commandSpec(node *kong.Node) CommandSpec {
+	name := node.Path()
+	spec := CommandSpec{
+		Name:            name,
+		Help:            node.Help,
+		Flags:           flagSpecs(node.Flags),
+		ExclusiveGroups: exclusiveGroups(node.Flags),
+		Examples:        helpExamples(name),
+	}
+	for _, arg := range node.Positional {
+		spec.Arguments = append(spec.Arguments, ArgumentSpec{
+			Name:     arg.Name,
+			Type:     valueType(arg.Target.Type()),
+			Help:     arg.Help,
+			Required: arg.Required,
+		})
+	}
+	if contract, ok := findCommandContract(name); ok {
+		envelope := "item"
+		if _, isList := contract.Envelope.FieldByName("Results"); isList {
+			envelope = "list"
+		}
+		spec.Output = &OutputSpec{Envelope: envelope, ItemType: contract.ItemType, Schema: "confluence schema " + name}
+	}
+	return spec
+}
+
+func flagSpecs(flags []*kong.Flag) []FlagSpec {
+	specs := []FlagSpec{}
+	for _, flag := range flags {
+		if flag.Hidden || flag.Name == "help" {
+			continue
+		}
+		spec := FlagSpec{
+			Name:     flag.Name,
+			Type:     valueType(flag.Target.Type()),
+			Help:     flag.Help,
+			Default:  flag.Default,
+			Required: flag.Required,
+			Env:      flag.Envs,
+		}
+		if flag.Enum != "" {
+			for _, value := range flag.EnumSlice() {
+				if value != "" {
+					spec.Enum = append(spec.Enum, value)
+				}
+			}
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// exclusiveGroups reads kong xor groups and the catalogue-only `exclusive`
+// tag used where commands enforce exclusivity with their own messages.
+func exclusiveGroups(flags []*kong.Flag) [][]string {
+	byName := map[string][]string{}
+	for _, flag := range flags {
+		names := append([]string{}, flag.Xor...)
+		if group := flag.Tag.Get("exclusive"); group != "" {
+			names = append(names, group)
+		}
+		for _, name := range names {
+			byName[name] = append(byName[name], flag.Name)
+		}
+	}
+	keys := make([]string, 0, len(byName))
+	for key := range byName {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var groups [][]string
+	for _, key := range keys {
+		groups = append(groups, byName[key])
+	}
+	return groups
+}
+
+func valueType(t reflect.Type) string {
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		return "duration"
+	case t.Kind() == reflect.Slice:
+		return valueType(t.Elem()) + "[]"
+	case t.Kind() == reflect.Bool:
+		return "boolean"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return "integer"
+	default:
+		return "string"
+	}
+}
+
+// helpExamples reuses the "Examples:" section of the hand-written help so the
+// catalogue and --help never disagree.
+func helpExamples(command string) []string {
+	text, ok := helpText(strings.Fields(command))
+	if !ok {
+		return nil
+	}
+	_, section, ok := strings.Cut(text, "\nExamples:\n")
+	if !ok {
+		return nil
+	}
+	var examples []string
+	for _, line := range strings.Split(section, "\n") {
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		examples = append(examples, strings.TrimSpace(line))
+	}
+	return examples
+}
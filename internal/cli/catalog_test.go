@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestHelpCatalogJSON(t *testing.T) {
+	t.Setenv("CONFLUENCE_CONFIG_DIR", t.TempDir())
+	stdout, stderr, code := runCLIForTest(t, []string{"help"}, false)
+	if code != ExitOK {
+		t.Fatalf("help exit code = %d, stderr=%s", code, stderr)
+	}
+
+	var envelope ItemEnvelope[CommandCatalog]
+	if err := json.Unmarshal([]byte(stdout), &envelope); err != nil {
+		t.Fatalf("parse catalog: %v\n%s", err, stdout)
+	}
+	catalog := envelope.Item
+	if envelope.Schema.ItemType != "command-catalog" || catalog.Name != "confluence" || catalog.Version != "test-version" {
+		t.Fatalf("unexpected catalog header: %+v %+v", envelope.Schema, catalog.Name)
+	}
+
+	commands := map[string]CommandSpec{}
+	for _, command := range catalog.Commands {
+		commands[command.Name] = command
+	}
+	for _, contract := range commandContracts {
+		if _, ok := commands[contract.Command]; !ok {
+			t.Errorf("catalog missing %q", contract.Command)
+		}
+	}
+
+	search := commands["pages search"]
+	if !reflect.DeepEqual(search.ExclusiveGroups, [][]string{{"query", "cql"}, {"space-id", "space-key"}}) {
+		t.Errorf("pages search exclusiveGroups = %v", search.ExclusiveGroups)
+	}
+	if search.Output == nil || search.Output.Envelope != "list" || search.Output.ItemType != "page-search-result" {
+		t.Errorf("pages search output = %+v", search.Output)
+	}
+	if len(search.Examples) == 0 {
+		t.Error("pages search has no examples")
+	}
+
+	get := commands["pages get"]
+	var pageID FlagSpec
+	for _, flag := range get.Flags {
+		if flag.Name == "page-id" {
+			pageID = flag
+		}
+	}
+	if !pageID.Required || pageID.Type != "string" {
+		t.Errorf("pages get --page-id = %+v", pageID)
+	}
+
+	if args := commands["config set"].Arguments; len(args) != 2 || args[0].Name != "key" || !args[1].Required {
+		t.Errorf("config set arguments = %+v", args)
+	}
+
+	for _, flag := range catalog.GlobalFlags {
+		if flag.Name == "timeout" && (flag.Type != "duration" || flag.Default != "30s") {
+			t.Errorf("timeout flag = %+v", flag)
+		}
+		if flag.Name == "flavor" && !reflect.DeepEqual(flag.Enum, []string{"cloud", "server"}) {
+			t.Errorf("flavor enum = %v", flag.Enum)
+		}
+	}
+}
+
+func TestHelpCatalogFiltersByCommand(t *testing.T) {
+	t.Setenv("CONFLUENCE_CONFIG_DIR", t.TempDir())
+	stdout, _, code := runCLIForTest(t, []string{"help", "pages"}, false)
+	if code != ExitOK {
+		t.Fatalf("help pages exit code = %d", code)
+	}
+	var envelope ItemEnvelope[CommandCatalog]
+	if err := json.Unmarshal([]byte(stdout), &envelope); err != nil {
+		t.Fatalf("parse catalog: %v", err)
+	}
+	if len(envelope.Item.Commands) != 7 {
+		t.Fatalf("expected 7 pages commands, got %d", len(envelope.Item.Commands))
+	}
+
+	_, _, code = runCLIForTest(t, []string{"help", "nope"}, false)
+	if code != ExitValidation {
+		t.Fatalf("unknown command exit code = %d, want %d", code, ExitValidation)
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"io"
+	"time"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+// newClient builds the API client for resolved credentials. Data Center
+// without an email uses the token as a personal access token (bearer).
+// Warnings that do not fail a request go to stderr.
+func newClient(creds Credentials, timeout time.Duration, stderr io.Writer) *confluence.Client {
+	if creds.OAuth != nil {
+		return newOAuthClient(creds, timeout, stderr)
+	}
+
+	opts := confluence.Options{
+		BaseURL: creds.URL,
+		Email:   creds.Email,
+		Token:   creds.Token,
+		Flavor:  creds.flavor(),
+		Timeout: timeout,
+	}
+	if opts.Flavor == confluence.FlavorServer && creds.Email == "" {
+		opts.Authenticator = confluence.BearerAuth{Token: creds.Token}
+	}
+	return confluence.NewClient(opts)
+}
@@ -0,0 +1,283 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+const defaultProfile = "default"
+
+// configExcludedFlags never come from the config file: help is meta, the token
+// belongs in the credential store, profile selects the config itself, a stored
+// cursor would pin every list to one page, and fields and filter differ per
+// command.
+var configExcludedFlags = map[string]bool{"help": true, "token": true, "profile": true, "cursor": true, "fields": true, "filter": true}
+
+// ConfigFile is a per-profile set of flag defaults keyed by dot-path, for
+// example "format" or "pages.list.limit".
+type ConfigFile struct {
+	Path    string
+	Format  string
+	Profile string
+	Values  map[string]string
+	raw     map[string]any
+}
+
+// configFilePath locates config.json or config.toml in configDir(); new
+// files default to JSON.
+func configFilePath() (string, string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", "", err
+	}
+	jsonPath := filepath.Join(dir, "config.json")
+	tomlPath := filepath.Join(dir, "config.toml")
+	_, jsonErr := os.Stat(jsonPath)
+	_, tomlErr := os.Stat(tomlPath)
+	switch {
+	case jsonErr == nil && tomlErr == nil:
+		return "", "", fmt.Errorf("both %s and %s exist; keep only one", jsonPath, tomlPath)
+	case tomlErr == nil:
+		return tomlPath, "toml", nil
+	default:
+		return jsonPath, "json", nil
+	}
+}
+
+func loadConfig(profile string) (*ConfigFile, error) {
+	path, format, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &ConfigFile{Path: path, Format: format, Profile: profile, Values: map[string]string{}, raw: map[string]any{}}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "toml" {
+		cfg.raw, err = parseTOML(string(b))
+	} else {
+		err = json.Unmarshal(b, &cfg.raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if section, ok := cfg.raw[profile]; ok {
+		table, ok := section.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("parse %s: profile %q must be a table", path, profile)
+		}
+		if err := flattenConfig(table, "", cfg.Values); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	return cfg, nil
+}
+
+func flattenConfig(table map[string]any, prefix string, out map[string]string) error {
+	for key, value := range table {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			if err := flattenConfig(v, path, out); err != nil {
+				return err
+			}
+		case string:
+			out[path] = v
+		case bool:
+			out[path] = strconv.FormatBool(v)
+		case float64:
+			out[path] = strconv.FormatFloat(v, 'f', -1, 64)
+		case int64:
+			out[path] = strconv.FormatInt(v, 10)
+		default:
+			return fmt.Errorf("%s: unsupported value type %T", path, value)
+		}
+	}
+	return nil
+}
+
+// Set stores value under key for the active profile and rewrites the file.
+func (cfg *ConfigFile) Set(key, value string) error {
+	cfg.Values[key] = value
+
+	table := map[string]any{}
+	for k, v := range cfg.Values {
+		parts := strings.Split(k, ".")
+		node := table
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = typedConfigValue(v)
+	}
+	cfg.raw[cfg.Profile] = table
+
+	var b []byte
+	var err error
+	if cfg.Format == "toml" {
+		b = []byte(encodeTOML(cfg.raw))
+	} else {
+		b, err = json.MarshalIndent(cfg.raw, "", "  ")
+		b = append(b, '\n')
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(cfg.Path, b, 0o600)
+}
+
+// typedConfigValue writes canonical integers and booleans unquoted so
+// hand-edited files stay idiomatic.
+func typedConfigValue(v string) any {
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil && strconv.FormatInt(n, 10) == v {
+		return n
+	}
+	if b, err := strconv.ParseBool(v); err == nil && strconv.FormatBool(b) == v {
+		return b
+	}
+	return v
+}
+
+// configFlag is a configurable flag and the dot-path key that addresses it.
+type configFlag struct {
+	Key  string
+	Flag *kong.Flag
+}
+
+func configKey(node *kong.Node, flag *kong.Flag) string {
+	if path := node.Path(); path != "" {
+		return strings.ReplaceAll(path, " ", ".") + "." + flag.Name
+	}
+	return flag.Name
+}
+
+// configurableFlags lists every flag that the config file may default, in
+// model order: globals first, then each command's own flags.
+func configurableFlags(model *kong.Application) []configFlag {
+	var out []configFlag
+	_ = kong.Visit(model.Node, func(visitable kong.Visitable, next kong.Next) error {
+		if node, ok := visitable.(*kong.Node); ok {
+			for _, flag := range node.Flags {
+				if flag.Hidden || configExcludedFlags[flag.Name] {
+					continue
+				}
+				out = append(out, configFlag{Key: configKey(node, flag), Flag: flag})
+			}
+		}
+		return next(nil)
+	})
+	return out
+}
+
+func findConfigFlag(model *kong.Application, key string) (configFlag, bool) {
+	for _, flag := range configurableFlags(model) {
+		if flag.Key == key {
+			return flag, true
+		}
+	}
+	return configFlag{}, false
+}
+
+func configKeys(model *kong.Application) []string {
+	flags := configurableFlags(model)
+	keys := make([]string, len(flags))
+	for i, flag := range flags {
+		keys[i] = flag.Key
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateConfigValue parses value with the flag's own mapper and enum so a
+// bad default fails at `config set` instead of on every later invocation.
+func validateConfigValue(flag *kong.Flag, value string) error {
+	target := reflect.New(flag.Target.Type()).Elem()
+	if err := flag.Parse(kong.ScanFromTokens(kong.Token{Type: kong.FlagValueToken, Value: value}), target); err != nil {
+		return err
+	}
+	if flag.Enum == "" {
+		return nil
+	}
+	for _, allowed := range flag.EnumSlice() {
+		if allowed == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(flag.EnumSlice(), ", "))
+}
+
+func envSet(flag *kong.Flag) (string, string, bool) {
+	for _, env := range flag.Envs {
+		if v, ok := os.LookupEnv(env); ok {
+			return env, v, true
+		}
+	}
+	return "", "", false
+}
+
+// configResolver feeds config defaults into kong below flags and env vars:
+// kong only consults resolvers for flags missing from the command line, and
+// flags whose env var is present are skipped here.
+func configResolver(cfg *ConfigFile) kong.Resolver {
+	return kong.ResolverFunc(func(_ *kong.Context, parent *kong.Path, flag *kong.Flag) (any, error) {
+		if configExcludedFlags[flag.Name] {
+			return nil, nil
+		}
+		if _, _, ok := envSet(flag); ok {
+			return nil, nil
+		}
+		node := parent.Node()
+		if node == nil {
+			return nil, nil
+		}
+		if value, ok := cfg.Values[configKey(node, flag)]; ok {
+			return value, nil
+		}
+		return nil, nil
+	})
+}
+
+// profileFromArgs finds the profile before kong parses, because the config
+// resolver has to be installed up front.
+func profileFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(arg, "--profile="); ok {
+			return v
+		}
+		if arg == "--profile" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("CONFLUENCE_PROFILE")); v != "" {
+		return v
+	}
+	return defaultProfile
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alecthomas/kong"
+)
+
+type ConfigGetCmd struct {
+	Key string `arg:"" help:"Config key such as format or pages.list.limit"`
+}
+
+type ConfigSetCmd struct {
+	Key   string `arg:"" help:"Config key such as format or pages.list.limit"`
+	Value string `arg:"" help:"Default value; validated like the matching flag"`
+}
+
+type ConfigListCmd struct{}
+
+// configValue reports the effective value of a configurable flag and where it
+// came from, using the precedence flag > env > config > default.
+func configValue(app *App, flag configFlag) ConfigValue {
+	for _, path := range app.kongCtx.Path {
+		if path.Flag == flag.Flag && !path.Resolved {
+			return ConfigValue{Key: flag.Key, Value: formatFlagValue(app.kongCtx.FlagValue(flag.Flag)), Source: "flag"}
+		}
+	}
+	if env, value, ok := envSet(flag.Flag); ok {
+		return ConfigValue{Key: flag.Key, Value: value, Source: "env", Env: env}
+	}
+	if value, ok := app.config.Values[flag.Key]; ok {
+		return ConfigValue{Key: flag.Key, Value: value, Source: "config"}
+	}
+	return ConfigValue{Key: flag.Key, Value: flag.Flag.Default, Source: "default"}
+}
+
+func formatFlagValue(value any) string {
+	if d, ok := value.(time.Duration); ok {
+		return d.String()
+	}
+	return fmt.Sprint(value)
+}
+
+func lookupConfigFlag(model *kong.Application, key string) (configFlag, error) {
+	flag, ok := findConfigFlag(model, key)
+	if !ok {
+		return configFlag{}, validationErrorf("Run `confluence config list` for valid keys.", "unknown config key %q", key)
+	}
+	return flag, nil
+}
+
+func (cmd *ConfigGetCmd) Run(app *App) error {
+	flag, err := lookupConfigFlag(app.kongCtx.Model, cmd.Key)
+	if err != nil {
+		return err
+	}
+
+	value := configValue(app, flag)
+	if app.IsPlain() {
+		discardWrite(fmt.Fprintf(app.Stdout, "%s=%s (%s)\n", value.Key, value.Value, value.Source))
+		return nil
+	}
+	return app.renderEnvelope(itemEnvelope(value, "config-value", []string{"key", "value", "source", "env"}))
+}
+
+func (cmd *ConfigSetCmd) Run(app *App) error {
+	flag, err := lookupConfigFlag(app.kongCtx.Model, cmd.Key)
+	if err != nil {
+		return err
+	}
+	if err := validateConfigValue(flag.Flag, cmd.Value); err != nil {
+		return validationErrorf(helpHint("config set"), "invalid value for %s: %v", cmd.Key, err)
+	}
+
+	if err := app.config.Set(cmd.Key, cmd.Value); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+
+	value := ConfigValue{Key: cmd.Key, Value: cmd.Value, Source: "config", Path: app.config.Path}
+	if app.IsPlain() {
+		discardWrite(fmt.Fprintf(app.Stdout, "Set %s=%s in %s [%s]\n", value.Key, value.Value, value.Path, app.config.Profile))
+		return nil
+	}
+	return app.renderEnvelope(itemEnvelope(value, "config-value", []string{"key", "value", "source", "path"}))
+}
+
+func (cmd *ConfigListCmd) Run(app *App) error {
+	flags := configurableFlags(app.kongCtx.Model)
+	listing := ConfigListing{
+		Profile: app.config.Profile,
+		Path:    app.config.Path,
+		Values:  make([]ConfigValue, len(flags)),
+	}
+	for i, flag := range flags {
+		listing.Values[i] = configValue(app, flag)
+	}
+
+	if app.IsPlain() {
+		discardWrite(fmt.Fprintf(app.Stdout, "Profile: %s\nFile: %s\n\n", listing.Profile, listing.Path))
+		tw := tabwriter.NewWriter(app.Stdout, 0, 0, 2, ' ', 0)
+		discardWrite(fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE"))
+		for _, value := range listing.Values {
+			discardWrite(fmt.Fprintf(tw, "%s\t%s\t%s\n", value.Key, value.Value, value.Source))
+		}
+		_ = tw.Flush()
+		return nil
+	}
+	return app.renderEnvelope(itemEnvelope(listing, "config-list", []string{"profile", "path", "values"}))
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	input := `# confluence-cli defaults
+[default]
+format = "plain"  # trailing comment
+timeout = '45s'
+
+[default.pages.list]
+limit = 25
+"sort" = "-modified-date"
+
+[work]
+pages.search.title-only = true
+`
+	got, err := parseTOML(input)
+	if err != nil {
+		t.Fatalf("parseTOML: %v", err)
+	}
+
+	values := map[string]string{}
+	if err := flattenConfig(got["default"].(map[string]any), "", values); err != nil {
+		t.Fatalf("flattenConfig: %v", err)
+	}
+	want := map[string]string{
+		"format":           "plain",
+		"timeout":          "45s",
+		"pages.list.limit": "25",
+		"pages.list.sort":  "-modified-date",
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Fatalf("default profile = %v, want %v", values, want)
+	}
+
+	work := got["work"].(map[string]any)["pages"].(map[string]any)["search"].(map[string]any)
+	if work["title-only"] != true {
+		t.Fatalf("work title-only = %#v, want true", work["title-only"])
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := map[string]string{
+		"[default]\nformat = [\"json\"]\n": "line 2",
+		"[default]\nformat\n":              "expected key = value",
+		"a = 1\na = 2\n":                   "duplicate key",
+		"[[profiles]]\n":                   "unsupported table header",
+		"a = 1\n[a]\n":                     "already a value",
+	}
+	for input, want := range tests {
+		if _, err := parseTOML(input); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseTOML(%q) error = %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestEncodeTOMLRoundTrip(t *testing.T) {
+	root := map[string]any{
+		"default": map[string]any{
+			"format": "plain",
+			"pages":  map[string]any{"list": map[string]any{"limit": int64(25)}},
+		},
+	}
+	encoded := encodeTOML(root)
+	want := "[default]\nformat = \"plain\"\n\n[default.pages.list]\nlimit = 25\n"
+	if encoded != want {
+		t.Fatalf("encodeTOML =\n%s\nwant\n%s", encoded, want)
+	}
+	decoded, err := parseTOML(encoded)
+	if err != nil {
+		t.Fatalf("parseTOML: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, root) {
+		t.Fatalf("round trip = %#v, want %#v", decoded, root)
+	}
+}
+
+func TestConfigKeysCoverCommandFlags(t *testing.T) {
+	t.Setenv("CONFLUENCE_CONFIG_DIR", t.TempDir())
+	stdout, _, code := runCLIForTest(t, []string{"--format", "plain", "config", "list"}, false)
+	if code != ExitOK {
+		t.Fatalf("config list exit code = %d", code)
+	}
+	for _, key := range []string{"format", "timeout", "pages.list.limit", "pages.get.body-format"} {
+		if !strings.Contains(stdout, key) {
+			t.Errorf("config list missing %q:\n%s", key, stdout)
+		}
+	}
+	for _, key := range []string{"token", "profile", "cursor"} {
+		for _, line := range strings.Split(stdout, "\n") {
+			if fields := strings.Fields(line); len(fields) > 0 && strings.HasSuffix(fields[0], key) {
+				t.Errorf("config list exposes excluded key %q", fields[0])
+			}
+		}
+	}
+}
+
+func TestProfileFromArgs(t *testing.T) {
+	t.Setenv("CONFLUENCE_PROFILE", "env-profile")
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--profile", "work", "version"}, "work"},
+		{[]string{"--profile=work", "version"}, "work"},
+		{[]string{"version"}, "env-profile"},
+		{[]string{"version", "--", "--profile", "x"}, "env-profile"},
+	}
+	for _, tc := range tests {
+		if got := profileFromArgs(tc.args); got != tc.want {
+			t.Errorf("profileFromArgs(%v) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The config file only needs tables of scalar values, so this handles the
+// TOML subset of [table.headers], bare/quoted/dotted keys, strings, integers,
+// floats, and booleans. Arrays and inline tables are rejected.
+
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func parseTOML(input string) (map[string]any, error) {
+	root := map[string]any{}
+	current := root
+
+	for i, line := range strings.Split(input, "\n") {
+		lineNo := i + 1
+		line = strings.TrimSpace(stripTOMLComment(line))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") || strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: unsupported table header %q", lineNo, line)
+			}
+			path, err := parseTOMLKey(strings.TrimSpace(line[1 : len(line)-1]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			if current, err = tomlTable(root, path); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			continue
+		}
+
+		keyPart, valuePart, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		path, err := parseTOMLKey(strings.TrimSpace(keyPart))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		value, err := parseTOMLValue(strings.TrimSpace(valuePart))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		table, err := tomlTable(current, path[:len(path)-1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		name := path[len(path)-1]
+		if _, exists := table[name]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNo, strings.Join(path, "."))
+		}
+		table[name] = value
+	}
+	return root, nil
+}
+
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func parseTOMLKey(raw string) ([]string, error) {
+	var parts []string
+	for raw != "" {
+		var part string
+		switch raw[0] {
+		case '"':
+			end := strings.Index(raw[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted key")
+			}
+			part, raw = raw[1:end+1], raw[end+2:]
+		default:
+			end := strings.IndexByte(raw, '.')
+			if end < 0 {
+				end = len(raw)
+			}
+			part, raw = strings.TrimSpace(raw[:end]), raw[end:]
+			if !tomlBareKey.MatchString(part) {
+				return nil, fmt.Errorf("invalid key %q", part)
+			}
+		}
+		parts = append(parts, part)
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			break
+		}
+		if raw[0] != '.' {
+			return nil, fmt.Errorf("invalid key near %q", raw)
+		}
+		raw = strings.TrimSpace(raw[1:])
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("empty key")
+	}
+	return parts, nil
+}
+
+func parseTOMLValue(raw string) (any, error) {
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		return strconv.Unquote(raw)
+	case strings.HasPrefix(raw, `'`):
+		if len(raw) < 2 || !strings.HasSuffix(raw, `'`) {
+			return nil, fmt.Errorf("unterminated literal string")
+		}
+		return raw[1 : len(raw)-1], nil
+	case raw == "true" || raw == "false":
+		return raw == "true", nil
+	case strings.HasPrefix(raw, "[") || strings.HasPrefix(raw, "{"):
+		return nil, fmt.Errorf("arrays and inline tables are not supported")
+	}
+	number := strings.ReplaceAll(raw, "_", "")
+	if n, err := strconv.ParseInt(number, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(number, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unsupported value %q", raw)
+}
+
+func tomlTable(root map[string]any, path []string) (map[string]any, error) {
+	table := root
+	for _, part := range path {
+		child, exists := table[part]
+		if !exists {
+			next := map[string]any{}
+			table[part] = next
+			table = next
+			continue
+		}
+		next, ok := child.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("key %q is already a value", part)
+		}
+		table = next
+	}
+	return table, nil
+}
+
+func encodeTOML(root map[string]any) string {
+	var b strings.Builder
+	writeTOMLTable(&b, nil, root)
+	return b.String()
+}
+
+func writeTOMLTable(b *strings.Builder, path []string, table map[string]any) {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var scalars, tables []string
+	for _, key := range keys {
+		if _, ok := table[key].(map[string]any); ok {
+			tables = append(tables, key)
+		} else {
+			scalars = append(scalars, key)
+		}
+	}
+
+	if len(path) > 0 && len(scalars) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "[%s]\n", tomlKeyPath(path))
+	}
+	for _, key := range scalars {
+		fmt.Fprintf(b, "%s = %s\n", tomlKeyPath([]string{key}), tomlValue(table[key]))
+	}
+	for _, key := range tables {
+		writeTOMLTable(b, append(append([]string{}, path...), key), table[key].(map[string]any))
+	}
+}
+
+func tomlKeyPath(path []string) string {
+	parts := make([]string, len(path))
+	for i, part := range path {
+		if tomlBareKey.MatchString(part) {
+			parts[i] = part
+		} else {
+			parts[i] = strconv.Quote(part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func tomlValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return strconv.Quote(fmt.Sprint(v))
+	}
+}
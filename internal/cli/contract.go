@@ -28,16 +28,28 @@ type PageWindow struct {
 }
 
 // ListEnvelope is the default success contract for paginated commands.
+// NotFound lists requested IDs that no result was returned for.
 type ListEnvelope[T any] struct {
-	Results []T        `json:"results"`
-	Page    PageWindow `json:"page"`
-	Schema  Schema     `json:"schema"`
+	Results  []T           `json:"results"`
+	Page     PageWindow    `json:"page"`
+	NotFound []string      `json:"notFound,omitempty"`
+	Resolved []ResolvedRef `json:"resolved,omitempty"`
+	Schema   Schema        `json:"schema"`
 }
 
 // ItemEnvelope is the default success contract for single-object commands.
 type ItemEnvelope[T any] struct {
-	Item   T      `json:"item"`
-	Schema Schema `json:"schema"`
+	Item     T             `json:"item"`
+	Resolved []ResolvedRef `json:"resolved,omitempty"`
+	Schema   Schema        `json:"schema"`
+}
+
+// ResolvedRef echoes an ID flag given as a URL, tiny link, or SPACEKEY:Title
+// together with the ID it resolved to.
+type ResolvedRef struct {
+	Flag  string `json:"flag"`
+	Input string `json:"input"`
+	ID    string `json:"id"`
 }
 
 // VersionInfo is the CLI-owned version payload.
@@ -50,6 +62,22 @@ type AuthLoginInfo struct {
 	StoredIn string `json:"storedIn"`
 }
 
+// ConfigValue is the CLI-owned payload for one config key and its source.
+type ConfigValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Env    string `json:"env,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+// ConfigListing is the CLI-owned config list payload.
+type ConfigListing struct {
+	Profile string        `json:"profile"`
+	Path    string        `json:"path"`
+	Values  []ConfigValue `json:"values"`
+}
+
 // SpaceSummary is the CLI-owned list shape for spaces.
 type SpaceSummary struct {
 	ID     string `json:"id"`
@@ -59,6 +87,19 @@ type SpaceSummary struct {
 	Status string `json:"status"`
 }
 
+// SpaceDetail is the CLI-owned detail shape for spaces.
+type SpaceDetail struct {
+	ID          string     `json:"id"`
+	Key         string     `json:"key"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	Status      string     `json:"status"`
+	Description string     `json:"description,omitempty"`
+	HomepageID  string     `json:"homepageId,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+}
+
 // PageSummary is the CLI-owned list shape for pages.
 type PageSummary struct {
 	ID            string `json:"id"`
@@ -95,15 +136,15 @@ type PageDetail struct {
 	CreatedAt  time.Time        `json:"createdAt,omitempty"`
 	Version    *PageVersionInfo `json:"version,omitempty"`
 	Body       *PageBody        `json:"body,omitempty"`
+	Breadcrumb []Breadcrumb     `json:"breadcrumb,omitempty"`
 }
 
-// SearchSummary is the CLI-owned page search shape.
-type SearchSummary struct {
-	ID      string `json:"id"`
-	Title   string `json:"title"`
-	SpaceID string `json:"spaceId,omitempty"`
-	Excerpt string `json:"excerpt,omitempty"`
-	URL     string `json:"url,omitempty"`
+// Breadcrumb is one ancestor of a page, listed root first. Folders and other
+// non-page ancestors may have an empty title.
+type Breadcrumb struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
 }
 
 // PageTreeNode is the CLI-owned bounded tree shape.
@@ -159,6 +200,20 @@ func newSpaceSummary(space confluence.Space) SpaceSummary {
 	}
 }
 
+func newSpaceDetail(space *confluence.Space) SpaceDetail {
+	return SpaceDetail{
+		ID:          space.ID,
+		Key:         space.Key,
+		Name:        space.Name,
+		Type:        space.Type,
+		Status:      space.Status,
+		Description: space.Description,
+		HomepageID:  space.HomepageID,
+		Labels:      space.Labels,
+		CreatedAt:   optionalTime(space.CreatedAt),
+	}
+}
+
 func newPageSummary(page confluence.Page) PageSummary {
 	summary := PageSummary{
 		ID:       page.ID,
@@ -224,12 +279,19 @@ func bodyFromPage(page *confluence.Page, bodyFormat string) *PageBody {
 	}
 }
 
-func newSearchSummary(result confluence.SearchResult) SearchSummary {
-	return SearchSummary{
-		ID:      result.ID,
-		Title:   result.Title,
-		SpaceID: result.SpaceID,
-		Excerpt: result.Excerpt,
-		URL:     result.URL,
+func newBreadcrumbs(ancestors []confluence.ContentRef) []Breadcrumb {
+	breadcrumbs := make([]Breadcrumb, len(ancestors))
+	for i, ancestor := range ancestors {
+		breadcrumbs[i] = Breadcrumb{ID: ancestor.ID, Type: ancestor.Type, Title: ancestor.Title}
+	}
+	return breadcrumbs
+}
+
+// optionalTime keeps an unknown timestamp out of the JSON rather than
+// serialising the zero time.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
 	}
+	return &t
 }
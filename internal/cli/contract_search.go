@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"time"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+// searchWith lists the optional enrichments pages search can add.
+var searchWith = []string{"breadcrumbs", "lastModified", "space"}
+
+// SearchSummary is the CLI-owned page search shape. spaceKey, spaceName,
+// lastModified, and breadcrumbs are only present when requested with --with.
+type SearchSummary struct {
+	ID           string          `json:"id"`
+	Title        string          `json:"title"`
+	SpaceID      string          `json:"spaceId,omitempty"`
+	SpaceKey     string          `json:"spaceKey,omitempty"`
+	SpaceName    string          `json:"spaceName,omitempty"`
+	Excerpt      string          `json:"excerpt,omitempty"`
+	Highlights   []HighlightSpan `json:"highlights,omitempty"`
+	URL          string          `json:"url,omitempty"`
+	LastModified *time.Time      `json:"lastModified,omitempty"`
+	Breadcrumbs  []Breadcrumb    `json:"breadcrumbs,omitempty"`
+}
+
+// HighlightSpan marks matched excerpt text as [start, end) character offsets.
+type HighlightSpan struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+func newSearchSummary(result confluence.SearchResult, with []string) SearchSummary {
+	summary := SearchSummary{
+		ID:         result.ID,
+		Title:      result.Title,
+		SpaceID:    result.SpaceID,
+		Excerpt:    result.Excerpt,
+		Highlights: newHighlightSpans(result.Highlights),
+		URL:        result.URL,
+	}
+	for _, enrichment := range with {
+		switch enrichment {
+		case "space":
+			summary.SpaceKey = result.SpaceKey
+			summary.SpaceName = result.SpaceName
+		case "lastModified":
+			summary.LastModified = optionalTime(result.LastModified)
+		case "breadcrumbs":
+			summary.Breadcrumbs = newBreadcrumbs(result.Ancestors)
+		}
+	}
+	return summary
+}
+
+// searchSummaryFields lists schema.fields for pages search, including the
+// enrichments requested with --with.
+func searchSummaryFields(with []string) []string {
+	fields := []string{"id", "title", "spaceId", "excerpt", "highlights", "url"}
+	for _, enrichment := range with {
+		switch enrichment {
+		case "space":
+			fields = append(fields, "spaceKey", "spaceName")
+		default:
+			fields = append(fields, enrichment)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Prisma-Labs-Dev/confluence-cli/internal/cql"
+)
+
+// CqlCmd groups offline CQL tools.
+type CqlCmd struct {
+	Lint CqlLintCmd `cmd:"" help:"Check CQL syntax, fields, and operators without calling the API"`
+}
+
+type CqlLintCmd struct {
+	CQL string `name:"cql" help:"CQL expression to check; omit to read it from piped stdin"`
+}
+
+// CQLLint is the CLI-owned result of a successful lint.
+type CQLLint struct {
+	CQL     string   `json:"cql"`
+	Valid   bool     `json:"valid"`
+	Fields  []string `json:"fields"`
+	OrderBy []string `json:"orderBy,omitempty"`
+}
+
+func (cmd *CqlLintCmd) Run(app *App) error {
+	hint := helpHint("cql lint")
+	query := cmd.CQL
+	if query == "" {
+		if isTerminal(int(os.Stdin.Fd())) {
+			return validationError("provide --cql or pipe a CQL expression on stdin", hint)
+		}
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("read CQL from stdin: %w", err)
+		}
+		query = strings.TrimSpace(string(input))
+	}
+
+	result, err := lintCQL(query)
+	if err != nil {
+		return err
+	}
+	lint := CQLLint{CQL: query, Valid: true, Fields: result.Fields, OrderBy: result.OrderBy}
+	if app.IsPlain() {
+		discardWrite(fmt.Fprintf(app.Stdout, "valid: fields %s\n", strings.Join(lint.Fields, ", ")))
+		return nil
+	}
+	return app.renderEnvelope(itemEnvelope(lint, "cql-lint", []string{"cql", "valid", "fields", "orderBy"}))
+}
+
+// lintCQL checks a query locally and reports failures as validation errors
+// whose hint is the linter's suggestion.
+func lintCQL(query string) (*cql.Result, error) {
+	result, err := cql.Lint(query)
+	var lintErr *cql.Error
+	if errors.As(err, &lintErr) {
+		return nil, validationError(lintErr.Error(), lintErr.Suggestion)
+	}
+	return result, err
+}
+
+// checkCQL gates a --cql query before it is sent. Syntax errors fail as
+// validation errors; unknown fields and operators only warn on stderr,
+// because Confluence accepts fields the local list does not know.
+func checkCQL(app *App, query string) error {
+	result, err := cql.Check(query)
+	var lintErr *cql.Error
+	if errors.As(err, &lintErr) {
+		return validationError(lintErr.Error(), lintErr.Suggestion)
+	}
+	if err != nil {
+		return err
+	}
+	for _, warning := range result.Warnings {
+		writeWarning(app.Stderr, "CQL_LINT", warning.Error(), warning.Suggestion)
+	}
+	return nil
+}
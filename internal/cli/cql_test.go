@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCQLLintCommand(t *testing.T) {
+	t.Setenv("CONFLUENCE_CONFIG_DIR", t.TempDir())
+
+	stdout, stderr, code := runCLIForTest(t, []string{"cql", "lint", "--cql", `type=page AND label in ("a","b") ORDER BY created desc`}, true)
+	if code != ExitOK {
+		t.Fatalf("valid lint exit = %d, stderr = %s", code, stderr)
+	}
+	for _, want := range []string{`"valid": true`, `"itemType": "cql-lint"`, `"created desc"`} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output missing %s:\n%s", want, stdout)
+		}
+	}
+
+	_, stderr, code = runCLIForTest(t, []string{"cql", "lint", "--cql", `type=page AND titel ~ "x"`}, true)
+	if code != ExitValidation {
+		t.Fatalf("invalid lint exit = %d, want %d", code, ExitValidation)
+	}
+	for _, want := range []string{`"code":"VALIDATION"`, "column 15", `"hint":"did you mean title?"`} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("stderr missing %s:\n%s", want, stderr)
+		}
+	}
+
+	_, stderr, code = runCLIForTest(t, []string{"cql", "lint"}, true)
+	if code != ExitValidation || !strings.Contains(stderr, "provide --cql or pipe") {
+		t.Errorf("missing input = %d %s", code, stderr)
+	}
+}
+
+func TestPagesSearchChecksCQLBeforeCalling(t *testing.T) {
+	called := false
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	})
+
+	var stdout bytes.Buffer
+	detail, code := app.runInProcess([]string{"pages", "search", "--cql", `title ~ "runbook`, "--format=json"}, &stdout)
+	if code != ExitValidation || !strings.Contains(detail.Message, "column 9") || !strings.Contains(detail.Hint, "close the string") {
+		t.Errorf("runInProcess = %d %+v", code, detail)
+	}
+	if called {
+		t.Error("CQL with a syntax error reached the API")
+	}
+
+	// Fields and operators the local list does not know are sent anyway.
+	for _, args := range [][]string{
+		{"pages", "search", "--cql", `text = "runbook"`},
+		{"search", "--cql", `type = page AND customfield = x`},
+	} {
+		called = false
+		if detail, code := app.runInProcess(args, &stdout); code != ExitOK || !called {
+			t.Errorf("%v = %d %+v, called %v", args, code, detail, called)
+		}
+	}
+}
+
+func TestCheckCQLWarnsOnStderr(t *testing.T) {
+	var stderr bytes.Buffer
+	if err := checkCQL(&App{Stderr: &stderr}, `type = page AND customfield = x`); err != nil {
+		t.Fatalf("checkCQL: %v", err)
+	}
+	for _, want := range []string{`"warning":{"code":"CQL_LINT"`, `unknown field \"customfield\"`} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr missing %s:\n%s", want, stderr.String())
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
 )
 
 const (
@@ -20,9 +22,38 @@ var (
 )
 
 type Credentials struct {
-	URL   string `json:"url"`
-	Email string `json:"email"`
-	Token string `json:"token"`
+	URL    string            `json:"url"`
+	Email  string            `json:"email"`
+	Token  string            `json:"token"`
+	Flavor string            `json:"flavor,omitempty"`
+	OAuth  *OAuthCredentials `json:"oauth,omitempty"`
+}
+
+func (c Credentials) flavor() string {
+	if c.Flavor == "" {
+		return confluence.FlavorCloud
+	}
+	return c.Flavor
+}
+
+// complete reports whether the credentials are sufficient to build a client.
+func (c Credentials) complete() bool {
+	switch {
+	case c.OAuth != nil:
+		return c.OAuth.CloudID != "" && c.OAuth.AccessToken != ""
+	case c.flavor() == confluence.FlavorServer:
+		// Data Center accepts a personal access token alone; email is optional.
+		return c.URL != "" && c.Token != ""
+	default:
+		return c.URL != "" && c.Email != "" && c.Token != ""
+	}
+}
+
+func missingCredentialsMessage(c Credentials) string {
+	if c.flavor() == confluence.FlavorServer {
+		return "missing credentials: provide --url and --token (personal access token) for --flavor server, or store them with `confluence auth login`"
+	}
+	return "missing credentials: provide --url, --email, and --token, or store them with `confluence auth login`"
 }
 
 func resolveCredentials(in Credentials) (Credentials, error) {
@@ -34,6 +65,13 @@ func resolveCredentials(in Credentials) (Credentials, error) {
 	if err != nil {
 		return Credentials{}, err
 	}
+	// A stored OAuth grant is used whole unless basic credentials are given explicitly.
+	if stored.OAuth != nil && in.Email == "" && in.Token == "" {
+		if in.URL != "" {
+			stored.URL = in.URL
+		}
+		return stored, nil
+	}
 	if in.URL == "" {
 		in.URL = stored.URL
 	}
@@ -43,6 +81,9 @@ func resolveCredentials(in Credentials) (Credentials, error) {
 	if in.Token == "" {
 		in.Token = stored.Token
 	}
+	if in.Flavor == "" {
+		in.Flavor = stored.Flavor
+	}
 	return in, nil
 }
 
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+// requestGlobalFlags are the global flags MCP and HTTP callers may set per
+// request; credentials, profile, and output format stay fixed by the server.
+var requestGlobalFlags = map[string]bool{"fields": true, "filter": true}
+
+// commandFlags returns a command's node and the flags a server caller may
+// set for it: the command's own flags followed by requestGlobalFlags.
+func commandFlags(model *kong.Application, command string) (*kong.Node, []*kong.Flag, error) {
+	var node *kong.Node
+	for _, leaf := range model.Leaves(true) {
+		if leaf.Path() == command {
+			node = leaf
+		}
+	}
+	if node == nil {
+		return nil, nil, fmt.Errorf("command %q not found", command)
+	}
+
+	var flags []*kong.Flag
+	for _, flag := range node.Flags {
+		if !flag.Hidden && flag.Name != "help" {
+			flags = append(flags, flag)
+		}
+	}
+	for _, flag := range model.Flags {
+		if requestGlobalFlags[flag.Name] {
+			flags = append(flags, flag)
+		}
+	}
+	return node, flags, nil
+}
+
+// camelFlagName spells a flag the way the JSON envelopes spell fields, for
+// HTTP query parameters and batch arguments: --body-format becomes bodyFormat.
+func camelFlagName(flag string) string {
+	parts := strings.Split(flag, "-")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// flagArgValues converts a JSON argument into flag values, checking it
+// against the flag's type so callers get a precise error.
+func flagArgValues(flag *kong.Flag, raw json.RawMessage) ([]string, error) {
+	if string(raw) == "null" {
+		return nil, nil
+	}
+	switch valueType(flag.Target.Type()) {
+	case "boolean":
+		var value bool
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("expected a boolean")
+		}
+		return []string{strconv.FormatBool(value)}, nil
+	case "integer":
+		var value int64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("expected an integer")
+		}
+		return []string{strconv.FormatInt(value, 10)}, nil
+	case "string[]":
+		var values []string
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return nil, fmt.Errorf("expected an array of strings")
+		}
+		return values, nil
+	default:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("expected a string")
+		}
+		return []string{value}, nil
+	}
+}
+
+// runInProcess parses args against a fresh command tree and runs the command
+// with this app's client and config, as Run would in a new process. It
+// returns the error detail and exit code Run would have reported.
+func (app *App) runInProcess(args []string, stdout io.Writer) (ErrorDetail, int) {
+	var cli CLI
+	parser, err := kong.New(&cli,
+		kong.Name("confluence"),
+		kong.Writers(io.Discard, io.Discard),
+		kong.Resolvers(configResolver(app.config)),
+	)
+	if err != nil {
+		return ErrorDetail{Code: "INTERNAL", Message: err.Error(), Hint: "Rebuild the binary or inspect the CLI wiring."}, ExitError
+	}
+	ctx, err := parser.Parse(args)
+	if err != nil {
+		return ErrorDetail{Code: "VALIDATION", Message: err.Error(), Hint: helpHint(commandHint(args))}, ExitValidation
+	}
+
+	child := &App{
+		Client:  app.Client,
+		Stdout:  stdout,
+		Stderr:  io.Discard,
+		Format:  cli.Format,
+		Version: app.Version,
+		URL:     app.URL,
+		Email:   app.Email,
+		Token:   app.Token,
+		Flavor:  app.Flavor,
+		Fields:  parseFields(cli.Fields),
+		config:  app.config,
+		kongCtx: ctx,
+
+		inProcess: true,
+	}
+	if err := child.configureOutput(&cli); err != nil {
+		return ErrorDetail{Code: "VALIDATION", Message: err.Error(), Hint: helpHint(commandName(ctx.Command()))}, ExitValidation
+	}
+	if child.Client != nil {
+		if err := child.resolveReferences(ctx); err != nil {
+			return classifyError(err)
+		}
+	}
+	if err := ctx.Run(child); err != nil {
+		return classifyError(err)
+	}
+	return ErrorDetail{}, ExitOK
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// projectable is implemented by the success envelopes so --fields and --filter
+// can read the advertised schema, the Go type behind each result or item, and
+// filter list results without losing their type.
+type projectable interface {
+	envelopeSchema() Schema
+	envelopeItemType() reflect.Type
+	filterResults(keep func(row []byte) bool) (projectable, error)
+	withResolved(resolved []ResolvedRef) projectable
+}
+
+func (e ListEnvelope[T]) withResolved(resolved []ResolvedRef) projectable {
+	e.Resolved = resolved
+	return e
+}
+
+func (e ItemEnvelope[T]) withResolved(resolved []ResolvedRef) projectable {
+	e.Resolved = resolved
+	return e
+}
+
+func (e ListEnvelope[T]) envelopeSchema() Schema { return e.Schema }
+
+func (e ListEnvelope[T]) envelopeItemType() reflect.Type { return reflect.TypeOf((*T)(nil)).Elem() }
+
+func (e ItemEnvelope[T]) envelopeSchema() Schema { return e.Schema }
+
+func (e ItemEnvelope[T]) envelopeItemType() reflect.Type { return reflect.TypeOf((*T)(nil)).Elem() }
+
+func (e ListEnvelope[T]) filterResults(keep func(row []byte) bool) (projectable, error) {
+	results := make([]T, 0, len(e.Results))
+	for _, result := range e.Results {
+		row, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		if keep(row) {
+			results = append(results, result)
+		}
+	}
+	e.Results = results
+	return e, nil
+}
+
+func (e ItemEnvelope[T]) filterResults(func([]byte) bool) (projectable, error) {
+	return nil, errFilterNeedsList
+}
@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// parseFields splits and dedupes the --fields values, keeping request order.
+func parseFields(values []string) []string {
+	var fields []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" || seen[field] {
+				continue
+			}
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// validFieldPaths lists the schema fields plus every nested dot-path reachable
+// through the item type's JSON tags.
+func validFieldPaths(schema Schema, itemType reflect.Type) []string {
+	var paths []string
+	for _, name := range schema.Fields {
+		paths = append(paths, name)
+		if field, ok := jsonField(itemType, name); ok {
+			paths = append(paths, nestedFieldPaths(field.Type, name, map[reflect.Type]bool{itemType: true})...)
+		}
+	}
+	return paths
+}
+
+func nestedFieldPaths(t reflect.Type, prefix string, seen map[reflect.Type]bool) []string {
+	t = fieldElemType(t)
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) || seen[t] {
+		return nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	var paths []string
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		path := prefix + "." + name
+		paths = append(paths, path)
+		paths = append(paths, nestedFieldPaths(t.Field(i).Type, path, seen)...)
+	}
+	return paths
+}
+
+func fieldElemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	t = fieldElemType(t)
+	if t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if jsonName(t.Field(i)) == name {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// projectEnvelope keeps only the requested fields in results/item and rewrites
+// schema.fields to match.
+func projectEnvelope(v projectable, fields []string, hint string) (any, error) {
+	schema := v.envelopeSchema()
+	valid := validFieldPaths(schema, v.envelopeItemType())
+	allowed := map[string]bool{}
+	for _, path := range valid {
+		allowed[path] = true
+	}
+	for _, field := range fields {
+		if !allowed[field] {
+			return nil, validationErrorf(hint, "unknown field %q for %s; valid fields: %s", field, schema.ItemType, strings.Join(valid, ", "))
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var envelope fieldObject
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return nil, err
+	}
+
+	paths := make([][]string, len(fields))
+	for i, field := range fields {
+		paths[i] = strings.Split(field, ".")
+	}
+	for i, entry := range envelope {
+		switch entry.Key {
+		case "results", "item":
+			envelope[i].Value = projectValue(entry.Value, paths)
+		case "schema":
+			envelope[i].Value = Schema{ItemType: schema.ItemType, Fields: fields}
+		}
+	}
+	return envelope, nil
+}
+
+// projectValue applies dot-paths to an object, or to each element of an array.
+func projectValue(value any, paths [][]string) any {
+	switch v := value.(type) {
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = projectValue(elem, paths)
+		}
+		return out
+	case fieldObject:
+		var out fieldObject
+		for _, head := range headsInOrder(paths) {
+			child, ok := v.get(head)
+			if !ok {
+				continue
+			}
+			var tails [][]string
+			whole := false
+			for _, path := range paths {
+				if path[0] != head {
+					continue
+				}
+				if len(path) == 1 {
+					whole = true
+					break
+				}
+				tails = append(tails, path[1:])
+			}
+			if !whole {
+				child = projectValue(child, tails)
+			}
+			out = append(out, fieldEntry{Key: head, Value: child})
+		}
+		if out == nil {
+			out = fieldObject{}
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+func headsInOrder(paths [][]string) []string {
+	var heads []string
+	seen := map[string]bool{}
+	for _, path := range paths {
+		if !seen[path[0]] {
+			seen[path[0]] = true
+			heads = append(heads, path[0])
+		}
+	}
+	return heads
+}
+
+// fieldObject is a JSON object that keeps key order, so projected output
+// lists fields in the order they were requested.
+type fieldObject []fieldEntry
+
+type fieldEntry struct {
+	Key   string
+	Value any
+}
+
+func (o fieldObject) get(key string) (any, bool) {
+	for _, entry := range o {
+		if entry.Key == key {
+			return entry.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (o fieldObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, entry := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func (o *fieldObject) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return err
+	}
+	object, ok := value.(fieldObject)
+	if !ok {
+		return &json.UnmarshalTypeError{Value: "non-object", Type: reflect.TypeOf(o)}
+	}
+	*o = object
+	return nil
+}
+
+// decodeOrdered decodes the next JSON value, using fieldObject for objects.
+func decodeOrdered(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := fieldObject{}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, fieldEntry{Key: keyToken.(string), Value: value})
+		}
+		_, err := decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []any{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := decoder.Token()
+		return array, err
+	default:
+		return token, nil
+	}
+}
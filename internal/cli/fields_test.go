@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	got := parseFields([]string{"id, title", "title,version.number", ""})
+	want := []string{"id", "title", "version.number"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseFields = %v, want %v", got, want)
+	}
+}
+
+func TestProjectEnvelopeList(t *testing.T) {
+	envelope := listEnvelope([]PageSummary{
+		{ID: "1", Title: "One", SpaceID: "S", Status: "current", VersionNumber: 3},
+		{ID: "2", Title: "Two", SpaceID: "S", Status: "current"},
+	}, 10, "next", "page-summary", []string{"id", "title", "spaceId", "status", "parentId", "versionNumber"})
+
+	projected, err := projectEnvelope(envelope, []string{"title", "id"}, "")
+	if err != nil {
+		t.Fatalf("projectEnvelope: %v", err)
+	}
+	b, err := json.Marshal(projected)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"results":[{"title":"One","id":"1"},{"title":"Two","id":"2"}],"page":{"limit":10,"nextCursor":"next"},"schema":{"itemType":"page-summary","fields":["title","id"]}}`
+	if string(b) != want {
+		t.Fatalf("projection =\n%s\nwant\n%s", b, want)
+	}
+}
+
+func TestProjectEnvelopeNestedPaths(t *testing.T) {
+	tree := PageTree{RootPageID: "1", Depth: 2, Children: []PageTreeNode{
+		{ID: "2", Title: "Child", Children: []PageTreeNode{{ID: "3", Title: "Grandchild"}}},
+	}}
+	envelope := itemEnvelope(tree, "page-tree", []string{"rootPageId", "depth", "limitPerLevel", "hasMoreChildren", "children"})
+
+	projected, err := projectEnvelope(envelope, []string{"rootPageId", "children.id", "children.children"}, "")
+	if err != nil {
+		t.Fatalf("projectEnvelope: %v", err)
+	}
+	b, _ := json.Marshal(projected)
+	want := `{"item":{"rootPageId":"1","children":[{"id":"2","children":[{"id":"3","title":"Grandchild","spaceId":"","status":""}]}]},"schema":{"itemType":"page-tree","fields":["rootPageId","children.id","children.children"]}}`
+	if string(b) != want {
+		t.Fatalf("projection =\n%s\nwant\n%s", b, want)
+	}
+}
+
+func TestProjectEnvelopeRejectsUnknownField(t *testing.T) {
+	envelope := itemEnvelope(PageDetail{ID: "1"}, "page-detail", []string{"id", "title", "version"})
+
+	_, err := projectEnvelope(envelope, []string{"id", "body"}, "hint")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want ValidationError", err)
+	}
+	for _, name := range []string{`"body"`, "version.number", "version.authorId"} {
+		if !strings.Contains(validationErr.Message, name) {
+			t.Errorf("message %q missing %s", validationErr.Message, name)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+var errFilterNeedsList = errors.New("--filter is only available for list commands")
+
+// parseFilter compiles the --filter JMESPath expression evaluated against each
+// list result, for example "versionNumber > `10` && contains(title, 'Runbook')".
+// A result is kept when the expression is truthy by JMESPath rules: anything
+// but false, null, and empty strings, arrays, and objects.
+func parseFilter(input string) (*jmespath.JMESPath, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, errors.New("empty expression")
+	}
+	return jmespath.Compile(input)
+}
+
+// filterEnvelope keeps the list results matching expr. The schema block is
+// unchanged because filtering never alters the result shape.
+func filterEnvelope(envelope projectable, expr *jmespath.JMESPath, hint string) (projectable, error) {
+	var evalErr error
+	filtered, err := envelope.filterResults(func(row []byte) bool {
+		if evalErr != nil {
+			return false
+		}
+		var value any
+		if evalErr = json.Unmarshal(row, &value); evalErr != nil {
+			return false
+		}
+		var result any
+		result, evalErr = expr.Search(value)
+		return evalErr == nil && filterTruthy(result)
+	})
+	if errors.Is(err, errFilterNeedsList) {
+		return nil, validationError(err.Error(), hint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if evalErr != nil {
+		return nil, validationError(fmt.Sprintf("evaluating --filter: %v", evalErr), hint)
+	}
+	return filtered, nil
+}
+
+func filterTruthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	}
+	return true
+}
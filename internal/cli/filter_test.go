@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFilterEnvelope(t *testing.T) {
+	envelope := listEnvelope([]PageSummary{
+		{ID: "1", Title: "Runbook", Status: "current", VersionNumber: 12},
+		{ID: "2", Title: "Notes", Status: "current", VersionNumber: 3},
+		{ID: "3", Title: "Old runbook", Status: "archived", VersionNumber: 40},
+		{ID: "4", Title: "Draft", Status: "draft"},
+	}, 10, "next", "page-summary", []string{"id", "title", "spaceId", "status", "parentId", "versionNumber"})
+
+	tests := map[string][]string{
+		"versionNumber > `10`":                          {"1", "3"},
+		"versionNumber > `10` && status == 'current'":   {"1"},
+		"contains(title, 'unbook') || id == '2'":        {"1", "2", "3"},
+		"!(status == 'current')":                        {"3", "4"},
+		"versionNumber == null":                         {"4"},
+		"versionNumber >= `3` && versionNumber <= `12`": {"1", "2"},
+		"parentId": {},
+		"status != 'draft' && starts_with(title, 'N') || `false`": {"2"},
+	}
+	for input, want := range tests {
+		expr, err := parseFilter(input)
+		if err != nil {
+			t.Fatalf("parseFilter(%q): %v", input, err)
+		}
+		filtered, err := filterEnvelope(envelope, expr, "")
+		if err != nil {
+			t.Fatalf("filterEnvelope(%q): %v", input, err)
+		}
+		list := filtered.(ListEnvelope[PageSummary])
+		got := []string{}
+		for _, result := range list.Results {
+			got = append(got, result.ID)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: ids = %v, want %v", input, got, want)
+		}
+		if list.Page.NextCursor != "next" || list.Schema.ItemType != "page-summary" {
+			t.Errorf("%s: page/schema changed: %+v %+v", input, list.Page, list.Schema)
+		}
+	}
+}
+
+func TestFilterEnvelopeErrors(t *testing.T) {
+	for _, input := range []string{"", "versionNumber >", "a = 1", "(a == `1`", "title == 'x", "a == `1` b"} {
+		if _, err := parseFilter(input); err == nil {
+			t.Errorf("parseFilter(%q): expected error", input)
+		}
+	}
+
+	var validationErr *ValidationError
+	expr, _ := parseFilter("contains(versionNumber, 'x')")
+	list := listEnvelope([]PageSummary{{ID: "1", VersionNumber: 2}}, 10, "", "page-summary", []string{"id"})
+	if _, err := filterEnvelope(list, expr, ""); !errors.As(err, &validationErr) {
+		t.Errorf("type error err = %v, want ValidationError", err)
+	}
+
+	expr, _ = parseFilter("version == `1`")
+	item := itemEnvelope(VersionInfo{}, "version", []string{"version"})
+	if _, err := filterEnvelope(item, expr, ""); !errors.As(err, &validationErr) {
+		t.Errorf("item envelope err = %v, want ValidationError", err)
+	}
+}
+
+func TestFilterFlagOnListCommand(t *testing.T) {
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"1","title":"Runbook","spaceId":"S","status":"current","version":{"number":12}},
+			{"id":"2","title":"Notes","spaceId":"S","status":"current","version":{"number":3}}
+		],"_links":{}}`))
+	})
+
+	var stdout bytes.Buffer
+	detail, code := app.runInProcess([]string{"--filter", "versionNumber > `10`", "pages", "list", "--space-id", "7", "--format=ndjson"}, &stdout)
+	if code != ExitOK {
+		t.Fatalf("runInProcess = %d %+v", code, detail)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != 2 || !strings.Contains(lines[0], `"id":"1"`) {
+		t.Errorf("filtered output =\n%s", stdout.String())
+	}
+
+	if _, code := app.runInProcess([]string{"--filter", "versionNumber >", "pages", "list", "--space-id", "7"}, &stdout); code != ExitValidation {
+		t.Errorf("invalid --filter exit = %d, want %d", code, ExitValidation)
+	}
+}
@@ -6,6 +6,22 @@ import (
 	"strings"
 )
 
+// globalFlagsHelp is the flag table for commands without flags of their own.
+const globalFlagsHelp = `Flags:
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+`
+
 func maybeWriteHelp(args []string, stdout io.Writer) bool {
 	if !containsHelpFlag(args) {
 		return false
@@ -55,20 +71,54 @@ func helpText(path []string) (string, bool) {
 		return spacesHelp(), true
 	case "spaces list":
 		return spacesListHelp(), true
+	case "spaces get":
+		return spacesGetHelp(), true
 	case "pages":
 		return pagesHelp(), true
 	case "pages list":
 		return pagesListHelp(), true
 	case "pages get":
 		return pagesGetHelp(), true
+	case "pages get-many":
+		return pagesGetManyHelp(), true
 	case "pages tree":
 		return pagesTreeHelp(), true
+	case "pages ancestors":
+		return pagesAncestorsHelp(), true
+	case "pages descendants":
+		return pagesDescendantsHelp(), true
 	case "pages search":
 		return pagesSearchHelp(), true
+	case "search":
+		return searchHelp(), true
+	case "cql":
+		return cqlHelp(), true
+	case "cql lint":
+		return cqlLintHelp(), true
 	case "auth":
 		return authHelp(), true
 	case "auth login":
 		return authLoginHelp(), true
+	case "config":
+		return configHelp(), true
+	case "config get":
+		return configGetHelp(), true
+	case "config set":
+		return configSetHelp(), true
+	case "config list":
+		return configListHelp(), true
+	case "batch":
+		return batchHelp(), true
+	case "mcp":
+		return mcpHelp(), true
+	case "mcp serve":
+		return mcpServeHelp(), true
+	case "serve":
+		return serveHelp(), true
+	case "schema":
+		return schemaHelp(), true
+	case "help":
+		return helpCommandHelp(), true
 	case "version":
 		return versionHelp(), true
 	default:
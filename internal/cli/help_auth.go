@@ -28,9 +28,21 @@ Supported input modes:
   3. Token from stdin with URL/email from flags or env:
        printf '%s' "$CONFLUENCE_API_TOKEN" | confluence --url https://example.atlassian.net --email you@example.com auth login --token-stdin
 
+  4. OAuth 2.0 (3LO) token import from stdin:
+       printf '{"url":"https://example.atlassian.net","oauth":{"cloudId":"CLOUD_ID","accessToken":"ACCESS","refreshToken":"REFRESH","clientId":"CLIENT_ID","clientSecret":"SECRET","expiresAt":"2026-01-01T00:00:00Z"}}' | confluence auth login --stdin-json
+
+  5. Data Center / Server personal access token:
+       printf '%s' "$CONFLUENCE_PAT" | confluence --flavor server --url https://confluence.example.com/confluence auth login --token-stdin
+
 Notes:
   - No interactive prompts are supported.
   - Stored credentials are used only when running read commands, not while logging in.
+  - OAuth requests go to https://api.atlassian.com/ex/confluence/{cloudId} with a bearer token.
+  - OAuth refreshToken requires clientId and clientSecret; rotated tokens are written back to the store.
+    If that save fails, the run continues and stderr gets an OAUTH_TOKEN_NOT_SAVED warning.
+  - Explicit --email/--token always select basic auth over a stored OAuth grant.
+  - --flavor server stores the flavor with the credentials; without --email the token is sent as a bearer PAT.
+  - Data Center URLs may include a context path such as /confluence.
 
 Output (json):
   {
@@ -39,13 +51,19 @@ Output (json):
   }
 
 Flags:
-  -h, --help            Show command help.
-      --url=STRING      Confluence base URL ($CONFLUENCE_URL)
-      --email=STRING    Atlassian account email ($CONFLUENCE_EMAIL)
-      --token=STRING    Atlassian API token ($CONFLUENCE_API_TOKEN)
-      --format=json     Output format: json or plain
-      --timeout=30s     HTTP timeout
-      --stdin-json      Read {url,email,token} JSON from piped stdin
-      --token-stdin     Read token from piped stdin; requires --url and --email
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --stdin-json          Read {url,email,token} or {url,oauth:{...}} JSON from piped stdin
+      --token-stdin         Read token from piped stdin; requires --url and --email (cloud)
 `
 }
@@ -0,0 +1,41 @@
+package cli
+
+import "fmt"
+
+func batchHelp() string {
+	return `Usage: confluence batch [flags]
+
+Run many read commands in one process with one client. Reads JSONL commands
+from piped stdin and writes one JSONL result per command as each completes.
+
+Input lines:
+  {"cmd":"pages get","args":{"pageId":"1","bodyFormat":"view"}}
+  {"cmd":"pages search","args":{"query":"runbook","limit":5,"fields":["id","title"]}}
+
+  - cmd is one of: spaces list, spaces get, pages list, pages get,
+    pages get-many, pages ancestors, pages descendants, pages tree,
+    pages search, search, version.
+  - args are the command flags in camelCase with JSON types, plus fields
+    (array of strings) and filter (string).
+  - Blank lines are skipped but still count toward the line index.
+
+Output lines:
+  {"index":0,"result":{"item":{...},"schema":{...}}}
+  {"index":1,"error":{"code":"API_ERROR","message":"...","hint":"..."}}
+
+  - index is the zero-based input line number; results arrive in completion
+    order, so sort by index if order matters.
+  - result is the envelope the command prints with --format json; error is
+    the detail of its error envelope.
+  - Per-line failures do not change the exit code. The exit code is non-zero
+    only when the batch itself cannot run (bad flags, credentials, stdin).
+  - --format, --fields, and --filter on the batch command do not apply to
+    lines; set fields and filter in args.
+
+Examples:
+  printf '%s\n' '{"cmd":"pages get","args":{"pageId":"1"}}' '{"cmd":"pages get","args":{"pageId":"2"}}' | confluence batch
+  confluence batch --concurrency 8 < requests.jsonl > results.jsonl
+
+` + globalFlagsHelp + fmt.Sprintf(`      --concurrency=4       Maximum commands running at once (1-%d)
+`, maxBatchConcurrency)
+}
@@ -0,0 +1,41 @@
+package cli
+
+func helpCommandHelp() string {
+	return `Usage: confluence help [<command> ...] [flags]
+
+Describe the full command tree for tool-using agents, generated from the
+parser model: commands, positional arguments, flags with types, defaults,
+enums and env vars, mutually exclusive flag groups, output itemType, and
+example invocations taken from each command's --help.
+
+Output (json):
+  {
+    "item": {
+      "name": "confluence",
+      "version": "1.2.3",
+      "globalFlags": [{"name":"format","type":"string","help":"...","default":"json","enum":["json","plain",...]}],
+      "commands": [
+        {
+          "name": "pages search",
+          "help": "...",
+          "flags": [{"name":"query","type":"string","help":"..."}],
+          "exclusiveGroups": [["query","cql"],["space-id","space-key"]],
+          "output": {"envelope":"list","itemType":"page-search-result","schema":"confluence schema pages search"},
+          "examples": ["confluence pages search --query \"deployment\""]
+        }
+      ]
+    },
+    "schema": {"itemType":"command-catalog","fields":["name","version","globalFlags","commands"]}
+  }
+
+Notes:
+  - Pass a command or group to limit the output, e.g. "confluence help pages".
+  - --format plain prints the same text as --help.
+
+Examples:
+  confluence help
+  confluence help pages search
+  confluence --format plain help pages
+
+` + globalFlagsHelp
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+type HelpCmd struct {
+	Command []string `arg:"" optional:"" help:"Limit output to a command or command group, e.g. pages"`
+}
+
+func (cmd *HelpCmd) Run(app *App) error {
+	if app.IsPlain() {
+		text, ok := helpText(cmd.Command)
+		if !ok {
+			return validationErrorf(helpHint("help"), "unknown command %q", strings.Join(cmd.Command, " "))
+		}
+		discardWrite(fmt.Fprint(app.Stdout, text))
+		return nil
+	}
+
+	catalog := buildCommandCatalog(app.kongCtx.Model, app.Version)
+	if prefix := strings.Join(cmd.Command, " "); prefix != "" {
+		var matched []CommandSpec
+		for _, command := range catalog.Commands {
+			if command.Name == prefix || strings.HasPrefix(command.Name, prefix+" ") {
+				matched = append(matched, command)
+			}
+		}
+		if len(matched) == 0 {
+			return validationErrorf(helpHint("help"), "unknown command %q", prefix)
+		}
+		catalog.Commands = matched
+	}
+	return app.renderEnvelope(itemEnvelope(catalog, "command-catalog", []string{"name", "version", "globalFlags", "commands"}))
+}
@@ -0,0 +1,105 @@
+package cli
+
+func configHelp() string {
+	return `Usage: confluence config <command>
+
+Per-profile flag defaults stored in config.json or config.toml next to the
+stored credentials.
+
+Precedence (highest first):
+  1. command-line flags
+  2. environment variables
+  3. config file values for the active --profile
+  4. built-in defaults
+
+Keys:
+  - Global flags use their name: format, timeout, url, email, flavor.
+  - Command flags are prefixed with the command path: pages.list.limit.
+  - token, profile, and cursor flags cannot be configured.
+
+File layout (config.toml):
+  [default]
+  format = "plain"
+
+  [default.pages.list]
+  limit = 25
+
+  [work]
+  url = "https://work.atlassian.net"
+
+Commands:
+  get <key>
+    Show the effective value of a key and where it came from.
+
+  set <key> <value>
+    Store a flag default in the active profile.
+
+  list
+    List every key with its effective value and source.
+
+Run "confluence config list --help" for the output shape.
+`
+}
+
+func configGetHelp() string {
+	return `Usage: confluence config get <key> [flags]
+
+Show the effective value of a config key and its source: flag, env, config,
+or default.
+
+Output (json):
+  {
+    "item": {"key":"format","value":"plain","source":"config"},
+    "schema": {"itemType":"config-value","fields":["key","value","source","env"]}
+  }
+
+Examples:
+  confluence config get format
+  confluence --profile work config get pages.list.limit
+
+` + globalFlagsHelp
+}
+
+func configSetHelp() string {
+	return `Usage: confluence config set <key> <value> [flags]
+
+Store a flag default in the active profile. Values are validated like the
+matching flag, so enums, integers, and durations are checked before writing.
+New files are written as config.json; an existing config.toml is kept as TOML.
+
+Output (json):
+  {
+    "item": {"key":"pages.list.limit","value":"25","source":"config","path":"/home/you/.config/confluence-cli/config.json"},
+    "schema": {"itemType":"config-value","fields":["key","value","source","path"]}
+  }
+
+Examples:
+  confluence config set format plain
+  confluence config set pages.list.limit 25
+  confluence --profile work config set url https://work.atlassian.net
+
+` + globalFlagsHelp
+}
+
+func configListHelp() string {
+	return `Usage: confluence config list [flags]
+
+List every config key with its effective value and source for the active
+profile.
+
+Output (json):
+  {
+    "item": {
+      "profile": "default",
+      "path": "/home/you/.config/confluence-cli/config.json",
+      "values": [{"key":"format","value":"json","source":"default"}]
+    },
+    "schema": {"itemType":"config-list","fields":["profile","path","values"]}
+  }
+
+Examples:
+  confluence config list
+  confluence --format plain --profile work config list
+
+` + globalFlagsHelp
+}
@@ -0,0 +1,63 @@
+package cli
+
+func cqlHelp() string {
+	return `Usage: confluence cql <command>
+
+Offline CQL tools. They never call the API and need no credentials.
+
+Commands:
+  lint [--cql=STRING] [flags]
+    Check CQL syntax, fields, and operators and report the error position.
+
+Run "confluence cql lint --help" for the output shape.
+`
+}
+
+func cqlLintHelp() string {
+	return `Usage: confluence cql lint [--cql=STRING] [flags]
+
+Check a CQL expression locally before spending an API call on it. pages
+search --cql and search --cql run the same check, but there only syntax
+errors fail; unknown fields and operators become CQL_LINT warnings on stderr,
+since Confluence accepts fields this list does not know.
+
+Checks:
+  - Syntax: quoting, parentheses, AND/OR/NOT, IN lists, functions such as
+    currentUser() and now("-4w"), and ORDER BY.
+  - Fields: documented CQL fields such as type, space, title, text, label,
+    ancestor, creator, contributor, created, and lastmodified, plus
+    content.property[key].path fields.
+  - Operators: text takes ~ and !~, dates take comparisons, and exact
+    fields take =, !=, in, and not in.
+
+Errors:
+  An invalid query exits 2 with a VALIDATION envelope. The message names the
+  1-based column and the hint carries a suggestion:
+  {"error":{"code":"VALIDATION","message":"invalid CQL at column 1: unknown field \"titel\"","hint":"did you mean title?"}}
+
+Output (json):
+  {
+    "item": {"cql":"type=page AND title ~ \"runbook\"","valid":true,"fields":["type","title"]},
+    "schema": {"itemType":"cql-lint","fields":["cql","valid","fields","orderBy"]}
+  }
+
+Examples:
+  confluence cql lint --cql 'type=page AND space in ("ENG","OPS") ORDER BY lastmodified desc'
+  echo 'label = runbook AND lastmodified >= now("-4w")' | confluence cql lint
+
+Flags:
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --cql=STRING          CQL expression to check; omit to read it from piped stdin
+`
+}
@@ -0,0 +1,60 @@
+package cli
+
+func mcpHelp() string {
+	return `Usage: confluence mcp <command>
+
+Model Context Protocol server commands.
+
+Commands:
+  serve [flags]
+    Serve the read commands as MCP tools over stdio.
+
+Run "confluence mcp serve --help" for the tool list and client setup.
+`
+}
+
+func mcpServeHelp() string {
+	return `Usage: confluence mcp serve [flags]
+
+Serve the read commands as Model Context Protocol tools over stdio.
+Messages are newline-delimited JSON-RPC 2.0 on stdin and stdout; the server
+exits when stdin closes.
+
+Tools:
+  spaces_list        confluence spaces list
+  spaces_get         confluence spaces get
+  pages_list         confluence pages list
+  pages_get          confluence pages get
+  pages_get_many     confluence pages get-many
+  pages_ancestors    confluence pages ancestors
+  pages_descendants  confluence pages descendants
+  pages_tree         confluence pages tree
+  pages_search       confluence pages search
+  search             confluence search
+  version            confluence version
+
+Tool arguments:
+  - Input schemas come from the command flags, with dashes replaced by
+    underscores: --page-id becomes page_id, --body-format becomes body_format.
+  - Every tool also accepts fields (array of strings) and filter (string).
+  - Config file defaults for the active --profile apply as they do on the CLI.
+
+Tool results:
+  - Success returns the command's JSON envelope as text and structuredContent.
+  - Failures return isError: true with the ErrorEnvelope, e.g.
+    {"error":{"code":"AUTH_FAILED","message":"...","hint":"..."}}
+  - Unknown tools and malformed requests are JSON-RPC errors.
+
+Notes:
+  - Credentials are resolved once at startup, like any other command.
+  - Nothing is written to stdout except JSON-RPC responses.
+
+Client configuration:
+  {"mcpServers":{"confluence":{"command":"confluence","args":["mcp","serve"]}}}
+
+Examples:
+  confluence mcp serve
+  confluence --profile work mcp serve
+
+` + globalFlagsHelp
+}
@@ -14,21 +14,45 @@ Commands:
   get [flags]
     Get page metadata by default; request body content explicitly.
 
+  get-many [flags]
+    Get many pages by ID in one call, reporting IDs that were not found.
+
   tree [flags]
     Traverse a bounded page tree with per-level limits.
 
+  ancestors [flags]
+    List the ancestors of a page, root first.
+
+  descendants [flags]
+    List everything below a page flat, with depth and parent per result.
+
   search [flags]
-    Search pages with safe query inputs or raw CQL.
+    Search pages with safe query inputs, structured filters, or raw CQL.
 
 Run "confluence pages <command> --help" for the live contract.
 `
 }
 
 func pagesListHelp() string {
-	return fmt.Sprintf(`Usage: confluence pages list --space-id=STRING [flags]
+	return fmt.Sprintf(`Usage: confluence pages list --space-id=STRING|--space-key=STRING [flags]
 
 List pages in a space with bounded summaries.
 
+Default behavior:
+  - Provide exactly one of --space-id or --space-key.
+  - --space-key is resolved to the space ID first; "resolved" echoes the
+    key and the ID it resolved to.
+
+Filters:
+  - --status current|archived|trashed and --title (exact match) map to
+    v2 query parameters.
+  - --created-after/--created-before and --modified-after/--modified-before
+    take YYYY-MM-DD; after is inclusive and before is exclusive. v2 has no
+    date filters, so these list pages through CQL search and then read the
+    matches in bulk; results keep the page-summary shape and cursor contract.
+  - Date filters and --flavor server list current pages only, and their
+    --sort takes title, created-date, or modified-date (no id).
+
 Output (json):
   {
     "results": [
@@ -40,25 +64,47 @@ Output (json):
 
 Pagination:
   - Results are bounded by --limit.
-  - Pass response.page.nextCursor back via --cursor.
+  - Pass response.page.nextCursor back via --cursor, or add --all to follow
+    it through every remaining page; with --format ndjson each page's rows
+    are written as soon as that page arrives.
 
 Examples:
   confluence pages list --space-id 12345
   confluence pages list --space-id 12345 --limit 20
+  confluence pages list --space-key ENG
+  confluence pages list --space-key ENG --status archived
+  confluence pages list --space-id 12345 --modified-after 2024-06-01 --sort=-modified-date
   confluence pages list --space-id 12345 --cursor abc123
   confluence --format plain pages list --space-id 12345
+  confluence --fields id,title pages list --space-id 12345
+  confluence --filter "contains(title, 'Runbook')" pages list --space-id 12345
+  confluence --format csv pages list --space-id 12345 --limit 100 > pages.csv
 
 Flags:
-  -h, --help               Show command help.
-      --url=STRING         Confluence base URL ($CONFLUENCE_URL)
-      --email=STRING       Atlassian account email ($CONFLUENCE_EMAIL)
-      --token=STRING       Atlassian API token ($CONFLUENCE_API_TOKEN)
-      --format=json        Output format: json or plain
-      --timeout=30s        HTTP timeout
-      --space-id=STRING    Space ID from spaces list output
-      --limit=%d           Maximum number of results per page (%d-%d)
-      --cursor=STRING      Opaque cursor from response.page.nextCursor
-      --sort=STRING        Sort order: title, created-date, or -modified-date
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --space-id=STRING     Space ID from spaces list output, or a space URL
+      --space-key=STRING    Space key such as ENG, instead of --space-id
+      --limit=%d            Maximum number of results per page (%d-%d)
+      --cursor=STRING       Opaque cursor from response.page.nextCursor
+      --all                 Follow nextCursor through every remaining page
+      --sort=STRING         Sort order: title, created-date, or -modified-date
+      --status=STRING       Only pages with this status: current, archived, or trashed
+      --title=STRING        Only pages with exactly this title
+      --created-after=DATE  Only pages created on or after this date (YYYY-MM-DD)
+      --created-before=DATE  Only pages created before this date (YYYY-MM-DD)
+      --modified-after=DATE  Only pages modified on or after this date (YYYY-MM-DD)
+      --modified-before=DATE  Only pages modified before this date (YYYY-MM-DD)
 `, defaultListLimit, defaultListLimit, 1, maxListLimit)
 }
 
@@ -70,6 +116,10 @@ Get a page by ID.
 Default behavior:
   - Returns metadata only.
   - Body content is omitted unless --body-format is set explicitly.
+  - atlas_doc_format is cloud-only; --flavor server supports view and storage.
+  - --page-id accepts an ID, a page URL, a tiny link, or SPACEKEY:Title. When it
+    is not already an ID, "resolved" echoes the input and the ID it resolved to.
+  - --breadcrumb adds the ancestor chain, root first, with one extra request.
 
 Output (json):
   {
@@ -79,9 +129,10 @@ Output (json):
       "spaceId":"...",
       "status":"...",
       "version":{"number":123},
-      "body":{"format":"view","value":"..."}
+      "body":{"format":"view","value":"..."},
+      "breadcrumb":[{"id":"...","type":"page","title":"..."}]
     },
-    "schema": {"itemType":"page-detail","fields":["id","title","spaceId","status","parentId","parentType","authorId","createdAt","version","body"]}
+    "schema": {"itemType":"page-detail","fields":["id","title","spaceId","status","parentId","parentType","authorId","createdAt","version","body","breadcrumb"]}
   }
 
 Examples:
@@ -89,16 +140,27 @@ Examples:
   confluence pages get --page-id 67890 --body-format view
   confluence --format plain pages get --page-id 67890 --body-format view
   confluence pages get --page-id 67890 --body-format storage
+  confluence --fields id,title,version.number pages get --page-id 67890
+  confluence pages get --page-id https://example.atlassian.net/wiki/spaces/ENG/pages/67890/Runbook
+  confluence pages get --page-id 'ENG:Release Runbook'
+  confluence pages get --page-id 67890 --breadcrumb
 
 Flags:
-  -h, --help                  Show command help.
-      --url=STRING            Confluence base URL ($CONFLUENCE_URL)
-      --email=STRING          Atlassian account email ($CONFLUENCE_EMAIL)
-      --token=STRING          Atlassian API token ($CONFLUENCE_API_TOKEN)
-      --format=json           Output format: json or plain
-      --timeout=30s           HTTP timeout
-      --page-id=STRING        Page ID from list/search output
-      --body-format=STRING    Optional body format: view, storage, atlas_doc_format
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --page-id=STRING      Page ID, page URL, tiny link, or SPACEKEY:Title
+      --body-format=STRING  Optional body format: view, storage, atlas_doc_format
+      --breadcrumb          Include the ancestor chain, root first, as breadcrumb
 `
 }
 
@@ -133,63 +195,20 @@ Examples:
   confluence --format plain pages tree --page-id 67890
 
 Flags:
-  -h, --help                    Show command help.
-      --url=STRING              Confluence base URL ($CONFLUENCE_URL)
-      --email=STRING            Atlassian account email ($CONFLUENCE_EMAIL)
-      --token=STRING            Atlassian API token ($CONFLUENCE_API_TOKEN)
-      --format=json             Output format: json or plain
-      --timeout=30s             HTTP timeout
-      --page-id=STRING          Root page ID
-      --depth=%d                Maximum traversal depth (%d-%d)
-      --limit-per-level=%d      Maximum children fetched per node (%d-%d)
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --page-id=STRING      Root page ID, page URL, tiny link, or SPACEKEY:Title
+      --depth=%d             Maximum traversal depth (%d-%d)
+      --limit-per-level=%d  Maximum children fetched per node (%d-%d)
 `, defaultTreeDepth, defaultTreeLimitPerLevel, defaultTreeDepth, defaultTreeLimitPerLevel, defaultTreeDepth, 1, maxTreeDepth, defaultTreeLimitPerLevel, 1, maxTreeLimitPerLevel)
 }
-
-func pagesSearchHelp() string {
-	return fmt.Sprintf(`Usage: confluence pages search (--query=STRING | --cql=STRING) [flags]
-
-Search pages with safe query inputs or raw CQL.
-
-Default behavior:
-  - Query mode searches full text unless --title-only is set.
-  - Query mode can be scoped with either --space-id or --space-key.
-  - Raw CQL mode is for advanced research queries and cannot be combined with query-only filters.
-  - Results are bounded by --limit.
-  - Internally this uses Atlassian's Confluence Cloud REST v1 search endpoint because REST v2 does not yet provide equivalent search capability.
-
-Output (json):
-  {
-    "results": [
-      {"id":"...","title":"...","spaceId":"...","excerpt":"...","url":"..."}
-    ],
-    "page": {"limit": %d, "nextCursor": "..."},
-    "schema": {"itemType":"page-search-result","fields":["id","title","spaceId","excerpt","url"]}
-  }
-
-Pagination:
-  - Pass response.page.nextCursor back via --cursor.
-  - Use query mode space filters or targeted CQL to keep results compact.
-
-Examples:
-  confluence pages search --query "deployment"
-  confluence pages search --query "meeting notes" --title-only
-  confluence pages search --query "runbook" --space-key TNLTA
-  confluence pages search --cql 'space = "SC" AND title ~ "slotting"'
-  confluence --format plain pages search --cql 'space = "TNLTA" AND text ~ "OutSystems"'
-
-Flags:
-  -h, --help               Show command help.
-      --url=STRING         Confluence base URL ($CONFLUENCE_URL)
-      --email=STRING       Atlassian account email ($CONFLUENCE_EMAIL)
-      --token=STRING       Atlassian API token ($CONFLUENCE_API_TOKEN)
-      --format=json        Output format: json or plain
-      --timeout=30s        HTTP timeout
-      --query=STRING       Search text to match in page content or titles
-      --cql=STRING         Raw CQL expression for advanced search
-      --title-only         Restrict matching to page titles (query mode only)
-      --space-id=STRING    Optional space ID filter (query mode only)
-      --space-key=STRING   Optional space key filter such as SC or TNLTA (query mode only)
-      --limit=%d           Maximum number of results per page (%d-%d)
-      --cursor=STRING      Opaque cursor from response.page.nextCursor
-`, defaultListLimit, defaultListLimit, 1, maxListLimit)
-}
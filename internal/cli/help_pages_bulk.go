@@ -0,0 +1,54 @@
+package cli
+
+import "fmt"
+
+func pagesGetManyHelp() string {
+	return fmt.Sprintf(`Usage: confluence pages get-many [--page-id=ID,...] [flags]
+
+Get many pages by ID in one call.
+
+Default behavior:
+  - Reads IDs from --page-id, or from piped stdin separated by whitespace
+    or commas when --page-id is omitted. Page URLs and tiny links work too.
+  - At most %d IDs per call; duplicates are fetched once.
+  - Uses the bulk id filter (cloud) or one id-in CQL search per chunk
+    (--flavor server) instead of one request per page.
+  - Missing, deleted, or inaccessible IDs are listed in notFound instead of
+    failing the call.
+  - Body content is omitted unless --body-format is set explicitly.
+
+Output (json):
+  {
+    "results": [{"id":"...","title":"...","spaceId":"...","status":"...","version":{"number":3}}],
+    "page": {"limit":3},
+    "notFound": ["..."],
+    "schema": {"itemType":"page-detail","fields":["id","title","spaceId","status","parentId","parentType","authorId","createdAt","version"]}
+  }
+
+Notes:
+  - results keep the order of the requested IDs.
+  - page.limit is the number of distinct IDs requested, so it equals the
+    results plus notFound; there is no nextCursor.
+
+Examples:
+  confluence pages get-many --page-id 101,102,103
+  confluence pages get-many --page-id 101,102 --body-format view
+  confluence --fields id pages search --query runbook | jq -r '.results[].id' | confluence pages get-many
+
+Flags:
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --page-id=ID,...      Comma-separated page IDs or URLs; omit to read them from piped stdin
+      --body-format=STRING  Optional body format: view, storage, atlas_doc_format
+`, maxGetManyIDs)
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+func pagesAncestorsHelp() string {
+	return fmt.Sprintf(`Usage: confluence pages ancestors --page-id=STRING [flags]
+
+List the ancestors of a page, root first, so agents can explain where a page
+lives.
+
+Default behavior:
+  - The first result is the top of the page tree (usually the space
+    homepage) and the last is the page's parent; the page itself is not
+    included.
+  - A page at the top of its space returns an empty results list.
+  - Cloud reads v2 /pages/{id}/ancestors and fetches page titles in one bulk
+    request; folders and other non-page ancestors keep an empty title.
+  - --page-id accepts an ID, a page URL, a tiny link, or SPACEKEY:Title.
+
+Output (json):
+  {
+    "results": [
+      {"id":"...","type":"page","title":"..."}
+    ],
+    "page": {"limit":%d},
+    "schema": {"itemType":"page-ancestor","fields":["id","type","title"]}
+  }
+
+Notes:
+  - page.limit is the most ancestors one call returns; the whole chain fits,
+    so there is no nextCursor.
+  - pages get --breadcrumb adds the same chain to page detail.
+
+Examples:
+  confluence pages ancestors --page-id 67890
+  confluence --format plain pages ancestors --page-id 'ENG:Release Runbook'
+
+Flags:
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --page-id=STRING      Page ID, page URL, tiny link, or SPACEKEY:Title
+`, confluence.MaxAncestors)
+}
+
+func pagesDescendantsHelp() string {
+	return fmt.Sprintf(`Usage: confluence pages descendants --page-id=STRING [flags]
+
+List everything below a page as one flat, cursor-paginated list.
+
+Default behavior:
+  - Each result carries its depth (1 for direct children) and parentId, so
+    the tree can be rebuilt without nesting.
+  - One request per page of results, instead of pages tree's request per
+    node; prefer it for bulk operations over whole sections.
+  - Cloud reads v2 /pages/{id}/descendants, which includes folders and
+    other content types; --flavor server lists descendant pages and drops
+    those deeper than --depth after fetching, so a page may hold fewer than
+    --limit results.
+  - --depth 0 sends no depth limit: --flavor server returns every level,
+    while Cloud still stops at its endpoint maximum of %d.
+
+Output (json):
+  {
+    "results": [
+      {"id":"...","title":"...","type":"page","status":"current","parentId":"...","depth":1}
+    ],
+    "page": {"limit": %d, "nextCursor": "..."},
+    "schema": {"itemType":"page-descendant","fields":["id","title","type","status","parentId","depth"]}
+  }
+
+Pagination:
+  - Pass response.page.nextCursor back via --cursor, or add --all to follow
+    it through every remaining page; with --format ndjson each page's rows
+    are written as soon as that page arrives.
+
+Examples:
+  confluence pages descendants --page-id 67890
+  confluence pages descendants --page-id 67890 --depth 1 --limit 100
+  confluence --format plain pages descendants --page-id 'ENG:Release Runbook'
+  confluence --format csv pages descendants --page-id 67890 --limit 100 > section.csv
+
+Flags:
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --page-id=STRING      Root page ID, page URL, tiny link, or SPACEKEY:Title
+      --depth=%d             Maximum levels below the page, or 0 for no limit (%d-%d)
+      --limit=%d            Maximum number of results per page (%d-%d)
+      --cursor=STRING       Opaque cursor from response.page.nextCursor
+      --all                 Follow nextCursor through every remaining page
+`, confluence.MaxDescendantDepth, defaultListLimit, confluence.MaxDescendantDepth, 0, confluence.MaxDescendantDepth, defaultListLimit, 1, maxListLimit)
+}
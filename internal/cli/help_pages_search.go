@@ -0,0 +1,99 @@
+package cli
+
+import "fmt"
+
+func pagesSearchHelp() string {
+	return fmt.Sprintf(`Usage: confluence pages search (--query=STRING | --cql=STRING | filters) [flags]
+
+Search pages with safe query inputs or raw CQL.
+
+Default behavior:
+  - Query mode searches full text unless --title-only is set.
+  - Query mode can be scoped with either --space-id or --space-key.
+  - Raw CQL mode is for advanced research queries and cannot be combined with query-only filters.
+  - Raw CQL is checked locally first; syntax errors exit 2 with the column and a suggestion, while unknown fields and operators only print a CQL_LINT warning on stderr.
+  - Results are bounded by --limit.
+  - Internally this uses Atlassian's Confluence Cloud REST v1 search endpoint because REST v2 does not yet provide equivalent search capability.
+
+Structured filters:
+  - Build escaped CQL so agents do not have to; they combine with --query with
+    AND, or stand alone without it. They cannot be combined with --cql.
+  - --space-key and --label take comma-separated values or repeated flags.
+    Several space keys match any of them; several labels must all be present.
+  - --creator and --contributor take an account ID (username on --flavor
+    server), or me for the authenticated user.
+  - --ancestor takes a page ID or page URL and matches pages anywhere below it.
+  - Date flags take YYYY-MM-DD; after is inclusive and before is exclusive.
+  - --order-by created|lastmodified|title; prefix - for descending.
+
+Output (json):
+  {
+    "results": [
+      {"id":"...","title":"...","spaceId":"...","excerpt":"...","highlights":[{"start":9,"end":17}],"url":"..."}
+    ],
+    "page": {"limit": %d, "nextCursor": "..."},
+    "schema": {"itemType":"page-search-result","fields":["id","title","spaceId","excerpt","highlights","url"]}
+  }
+
+Enrichment (--with, comma-separated):
+  - breadcrumbs: ancestors root first as [{"id","type","title"}].
+  - lastModified: RFC 3339 time of the last edit.
+  - space: spaceKey and spaceName.
+  - Only requested fields are fetched and added to results and schema.fields,
+    so ranking needs no follow-up pages get per hit.
+
+Excerpts:
+  - Plain text: highlight markers are removed and HTML entities decoded.
+  - highlights lists matched spans as [start, end) character offsets.
+  - --format plain shows matched spans in **bold**.
+
+Pagination:
+  - Pass response.page.nextCursor back via --cursor, or add --all to follow
+    it through every remaining page; with --format ndjson each page's rows
+    are written as soon as that page arrives.
+  - Use query mode space filters or targeted CQL to keep results compact.
+
+Examples:
+  confluence pages search --query "deployment"
+  confluence pages search --query "meeting notes" --title-only
+  confluence pages search --query "runbook" --space-key TNLTA
+  confluence pages search --label runbook --space-key ENG,OPS --modified-after 2024-06-01 --order-by=-lastmodified
+  confluence pages search --contributor me --ancestor 67890
+  confluence pages search --query "runbook" --with breadcrumbs,lastModified
+  confluence pages search --cql 'space = "SC" AND title ~ "slotting"'
+  confluence --format plain pages search --cql 'space = "TNLTA" AND text ~ "OutSystems"'
+  confluence --format template --template '{{range .Results}}- [{{md .Title}}]({{.URL}}){{"\n"}}{{end}}' pages search --query "runbook"
+
+Flags:
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --query=STRING        Search text to match in page content or titles
+      --cql=STRING          Raw CQL expression for advanced search
+      --title-only          Restrict matching to page titles (query mode only)
+      --space-id=STRING     Optional space ID or space URL filter (query mode only)
+      --space-key=KEY,...   Optional space key filter such as SC or TNLTA; repeat for any of several (query mode only)
+      --label=LABEL,...     Only pages with this label; repeat to require several
+      --creator=STRING      Only pages created by this account ID, or me
+      --contributor=STRING  Only pages edited by this account ID, or me
+      --ancestor=STRING     Only pages below this page ID or page URL
+      --created-after=DATE  Only pages created on or after this date (YYYY-MM-DD)
+      --created-before=DATE  Only pages created before this date (YYYY-MM-DD)
+      --modified-after=DATE  Only pages modified on or after this date (YYYY-MM-DD)
+      --modified-before=DATE  Only pages modified before this date (YYYY-MM-DD)
+      --order-by=STRING     Order by created, lastmodified, or title; prefix - for descending
+      --with=FIELD,...      Add breadcrumbs, lastModified, or space (key and name) to each result
+      --limit=%d            Maximum number of results per page (%d-%d)
+      --cursor=STRING       Opaque cursor from response.page.nextCursor
+      --all                 Follow nextCursor through every remaining page
+`, defaultListLimit, defaultListLimit, 1, maxListLimit)
+}
@@ -8,39 +8,94 @@ Agent-first Confluence Cloud CLI for compact, read-oriented access.
 Default behavior:
   - JSON envelopes on stdout
   - structured JSON errors on stderr
+  - --format ndjson writes one compact result per line, then a {"page","schema"} trailer;
+    with --all, rows are written as each upstream page arrives
+  - --format csv|tsv writes list results with schema.fields as the header row
+  - --format template renders --template/--template-file (Go text/template) against
+    the envelope; helpers: md, truncate, date, json
+  - --filter keeps the list results a JMESPath expression is truthy for; single-item
+    commands reject it. It is not named --query because pages search and search
+    already take --query as search text
   - explicit bounded output defaults
   - credentials from flags/env first, then stored credentials
+  - flag defaults per --profile from config.json or config.toml
+  - --flavor server targets Confluence Data Center / Server with a personal access token
+  - --page-id and --space-id also accept Confluence URLs, tiny links (/x/...), and
+    SPACEKEY:Title; resolved IDs are echoed in the envelope's "resolved" array
 
 Global flags:
-  -h, --help              Show command help.
-      --url=STRING        Confluence base URL ($CONFLUENCE_URL)
-      --email=STRING      Atlassian account email ($CONFLUENCE_EMAIL)
-      --token=STRING      Atlassian API token ($CONFLUENCE_API_TOKEN)
-      --format=json       Output format: json or plain
-      --timeout=30s       HTTP timeout
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
 
 Commands:
   spaces list [flags]
     List spaces with compact summaries and cursor pagination.
 
-  pages list --space-id=STRING [flags]
+  spaces get --space-id=STRING|--space-key=STRING [flags]
+    Get one space with its description, homepage, type, and labels.
+
+  pages list --space-id=STRING|--space-key=STRING [flags]
     List pages in a space with bounded summaries.
 
   pages get --page-id=STRING [flags]
     Get page metadata by default; request body content explicitly.
 
+  pages get-many [--page-id=ID,...] [flags]
+    Get many pages by ID in one call, reporting IDs that were not found.
+
   pages tree --page-id=STRING [flags]
     Traverse a bounded page tree with per-level limits.
 
-  pages search --query=STRING|--cql=STRING [flags]
-    Search pages with safe query inputs or raw CQL.
+  pages ancestors --page-id=STRING [flags]
+    List the ancestors of a page, root first, from v2 ancestors.
+
+  pages descendants --page-id=STRING [flags]
+    List everything below a page flat, with depth and parent per result.
+
+  pages search --query=STRING|--cql=STRING|filters [flags]
+    Search pages with safe query inputs, structured filters, or raw CQL.
+
+  search --query=STRING|--cql=STRING [flags]
+    Search every content type with typed results and comment containers.
+
+  cql lint [--cql=STRING] [flags]
+    Check CQL syntax, fields, and operators offline with error positions.
 
   auth login [flags]
     Store credentials for later non-interactive use.
 
+  config get|set|list [flags]
+    Read and write per-profile flag defaults.
+
+  batch [flags]
+    Run read commands from JSONL stdin with bounded concurrency.
+
+  mcp serve [flags]
+    Serve the read commands as MCP tools over stdio.
+
+  serve [flags]
+    Serve the read commands as a local HTTP API with the same envelopes.
+
+  schema [<command> ...] [flags]
+    Print JSON Schema for command output envelopes and errors.
+
+  help [<command> ...] [flags]
+    Describe commands, flags, and output contracts as JSON for agents.
+
   version [flags]
     Print the CLI version.
 
+Run "confluence help" for a machine-readable command catalogue.
 Run "confluence <command> --help" for output shapes, pagination rules, and examples.
 `
 }
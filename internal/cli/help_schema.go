@@ -0,0 +1,32 @@
+package cli
+
+func schemaHelp() string {
+	return `Usage: confluence schema [<command> ...] [flags]
+
+Print JSON Schema (draft 2020-12) for a command's default JSON success
+envelope, generated from the CLI contract structs. The same files are
+published in contract/schemas and are drift-tested against the code.
+
+Arguments:
+  [<command> ...]    Command path such as "pages get", or "error" for the
+                     stderr error envelope. Omit to print every schema.
+
+Output (json):
+  confluence schema pages get
+    {"$schema":"https://json-schema.org/draft/2020-12/schema","title":"confluence pages get",...}
+
+  confluence schema
+    {"commands":{"pages get":{...},...},"error":{...}}
+
+Notes:
+  - Schemas describe --format json output; --fields, --filter, and other
+    formats reshape or drop parts of the envelope.
+  - schema.itemType is pinned with "const" for each command.
+
+Examples:
+  confluence schema
+  confluence schema pages search
+  confluence schema error
+
+` + globalFlagsHelp
+}
@@ -0,0 +1,62 @@
+package cli
+
+import "fmt"
+
+func searchHelp() string {
+	return fmt.Sprintf(`Usage: confluence search (--query=STRING | --cql=STRING) [flags]
+
+Search pages, blog posts, comments, attachments, and spaces with typed results.
+
+Default behavior:
+  - Query mode matches text in every content type unless --type narrows it.
+  - Comments and attachments carry the page or blog post that holds them as
+    container, so a hit in a comment leads back to its page.
+  - Raw CQL is checked locally first: syntax errors exit 2, unknown fields
+    and operators print a CQL_LINT warning on stderr. It cannot be combined
+    with --type or --space-key.
+  - Use pages search for page-only filters such as labels, people, and dates.
+  - Excerpts are plain text with highlights as [start, end) character
+    offsets; --format plain shows them in **bold**.
+
+Output (json):
+  {
+    "results": [
+      {"id":"...","type":"comment","title":"...","spaceKey":"...","container":{"id":"...","type":"page","title":"..."},"lastModified":"...","excerpt":"...","highlights":[{"start":9,"end":17}],"url":"..."}
+    ],
+    "page": {"limit": %d, "nextCursor": "..."},
+    "schema": {"itemType":"search-result","fields":["id","type","title","spaceKey","container","lastModified","excerpt","highlights","url"]}
+  }
+
+Pagination:
+  - Pass response.page.nextCursor back via --cursor, or add --all to follow
+    it through every remaining page; with --format ndjson each page's rows
+    are written as soon as that page arrives.
+
+Examples:
+  confluence search --query "ADR postgres"
+  confluence search --query "ADR" --type comment,attachment --space-key ENG
+  confluence search --cql 'type=blogpost AND lastmodified >= now("-4w")'
+  confluence --format plain search --query "runbook"
+
+Flags:
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --query=STRING        Search text to match in any content
+      --cql=STRING          Raw CQL expression for advanced search
+      --type=TYPE,...       Only these types: page, blogpost, comment, attachment, space (query mode only)
+      --space-key=KEY,...   Only content in these space keys (query mode only)
+      --limit=%d            Maximum number of results per page (%d-%d)
+      --cursor=STRING       Opaque cursor from response.page.nextCursor
+      --all                 Follow nextCursor through every remaining page
+`, defaultListLimit, defaultListLimit, 1, maxListLimit)
+}
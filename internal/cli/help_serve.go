@@ -0,0 +1,54 @@
+package cli
+
+func serveHelp() string {
+	return `Usage: confluence serve [flags]
+
+Serve the read commands as a local HTTP API so other services can read
+Confluence without holding credentials. Credentials and --profile defaults
+are resolved once at startup.
+
+Routes:
+  GET /spaces                      confluence spaces list
+  GET /spaces/{spaceId}            confluence spaces get
+  GET /pages?spaceId=ID            confluence pages list
+  GET /pages/{pageId}              confluence pages get
+  GET /pages/get-many?pageIds=A,B  confluence pages get-many
+  GET /pages/{pageId}/ancestors    confluence pages ancestors
+  GET /pages/{pageId}/descendants  confluence pages descendants
+  GET /pages/{pageId}/tree         confluence pages tree
+  GET /pages/search?query=Q        confluence pages search
+  GET /search?query=Q              confluence search
+  GET /version                     confluence version
+
+Query parameters:
+  - Command flags in camelCase: bodyFormat, spaceId, limitPerLevel, titleOnly.
+  - fields and filter behave like --fields and --filter.
+  - Unknown parameters return 400 with a VALIDATION error.
+
+Responses:
+  - 200 with the same JSON envelope the command prints with --format json.
+  - Errors use the stderr ErrorEnvelope as the body:
+      400  VALIDATION (exit code 2)
+      4xx  AUTH_FAILED or API_ERROR carry Confluence's own 4xx status,
+           so a missing page is 404 and a forbidden one is 403
+      502  API_ERROR for any other Confluence failure
+      500  any other failure
+  - Unknown routes return 404.
+
+Notes:
+  - One JSON log line per request goes to stderr with method, path, status,
+    and durationMs.
+  - SIGINT or SIGTERM stops accepting connections and waits up to
+    --shutdown-grace for in-flight requests.
+  - There is no authentication on the API; keep --listen on loopback.
+
+Examples:
+  confluence serve
+  confluence serve --listen 127.0.0.1:9000
+  curl -s 'http://127.0.0.1:8787/pages/12345?bodyFormat=view'
+  curl -s 'http://127.0.0.1:8787/pages/search?query=runbook&fields=id,title'
+
+` + globalFlagsHelp + `      --listen=ADDR         Address to listen on (default 127.0.0.1:8787)
+      --shutdown-grace=10s  How long SIGINT/SIGTERM waits for in-flight requests
+`
+}
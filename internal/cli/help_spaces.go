@@ -11,7 +11,10 @@ Commands:
   list [flags]
     List spaces with compact summaries and cursor pagination.
 
-Run "confluence spaces list --help" for the live contract.
+  get [flags]
+    Get one space with its description, homepage, type, and labels.
+
+Run "confluence spaces <command> --help" for the live contract.
 `
 }
 
@@ -20,6 +23,13 @@ func spacesListHelp() string {
 
 List spaces with compact summaries.
 
+Filters:
+  - --type global|personal and --status current|archived narrow the listing.
+  - --space-key and --label take comma-separated values or repeated flags;
+    a space matches any of the given keys or labels.
+  - --favourite keeps spaces the authenticated user marked as favourite.
+  - --sort orders by id, key, or name; prefix - for descending. Cloud only.
+
 Output (json):
   {
     "results": [
@@ -31,22 +41,76 @@ Output (json):
 
 Pagination:
   - Results are bounded by --limit.
-  - Pass response.page.nextCursor back via --cursor.
+  - Pass response.page.nextCursor back via --cursor, or add --all to follow
+    it through every remaining page; with --format ndjson each page's rows
+    are written as soon as that page arrives.
 
 Examples:
   confluence spaces list
   confluence spaces list --limit 25
   confluence spaces list --cursor abc123
+  confluence spaces list --type global --status current --sort name
+  confluence spaces list --label team,platform --favourite
   confluence --format plain spaces list
 
 Flags:
-  -h, --help              Show command help.
-      --url=STRING        Confluence base URL ($CONFLUENCE_URL)
-      --email=STRING      Atlassian account email ($CONFLUENCE_EMAIL)
-      --token=STRING      Atlassian API token ($CONFLUENCE_API_TOKEN)
-      --format=json       Output format: json or plain
-      --timeout=30s       HTTP timeout
-      --limit=%d          Maximum number of results per page (%d-%d)
-      --cursor=STRING     Opaque cursor from response.page.nextCursor
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
+      --limit=%d            Maximum number of results per page (%d-%d)
+      --cursor=STRING       Opaque cursor from response.page.nextCursor
+      --all                 Follow nextCursor through every remaining page
+      --type=STRING         Only spaces of this type: global or personal
+      --status=STRING       Only spaces with this status: current or archived
+      --space-key=KEY,...   Only these space keys
+      --label=LABEL,...     Only spaces with any of these labels
+      --favourite           Only spaces the caller marked as favourite
+      --sort=STRING         Sort order: id, key, or name; prefix - for descending
 `, defaultListLimit, defaultListLimit, 1, maxListLimit)
 }
+
+func spacesGetHelp() string {
+	return `Usage: confluence spaces get --space-id=STRING|--space-key=STRING [flags]
+
+Get one space by ID or key.
+
+Default behavior:
+  - Provide exactly one of --space-id or --space-key.
+  - --space-key is looked up via /spaces?keys= (cloud) or /space/{key}
+    (--flavor server); no need to page through spaces list first.
+  - Returns the plain-text description, homepage ID, type, and labels.
+
+Output (json):
+  {
+    "item": {
+      "id":"...",
+      "key":"ENG",
+      "name":"...",
+      "type":"global",
+      "status":"current",
+      "description":"...",
+      "homepageId":"...",
+      "labels":["..."]
+    },
+    "schema": {"itemType":"space-detail","fields":["id","key","name","type","status","description","homepageId","labels","createdAt"]}
+  }
+
+Examples:
+  confluence spaces get --space-key ENG
+  confluence spaces get --space-id 98765
+  confluence --format plain spaces get --space-key ENG
+  confluence --fields id,homepageId spaces get --space-key ENG
+
+` + globalFlagsHelp + `      --space-id=STRING     Space ID from spaces list output, or a space URL
+      --space-key=STRING    Space key such as ENG
+`
+}
@@ -16,11 +16,17 @@ Examples:
   confluence --format plain version
 
 Flags:
-  -h, --help              Show command help.
-      --url=STRING        Confluence base URL ($CONFLUENCE_URL)
-      --email=STRING      Atlassian account email ($CONFLUENCE_EMAIL)
-      --token=STRING      Atlassian API token ($CONFLUENCE_API_TOKEN)
-      --format=json       Output format: json or plain
-      --timeout=30s       HTTP timeout
+  -h, --help                Show command help.
+      --url=STRING          Confluence base URL ($CONFLUENCE_URL)
+      --email=STRING        Atlassian account email ($CONFLUENCE_EMAIL)
+      --token=STRING        Atlassian API token ($CONFLUENCE_API_TOKEN)
+      --flavor=STRING       Deployment flavor: cloud or server ($CONFLUENCE_FLAVOR)
+      --format=json         Output format: json, plain, ndjson, csv, tsv, or template
+      --timeout=30s         HTTP timeout
+      --fields=FIELD,...    Comma-separated JSON fields to keep, e.g. id,title,version.number
+      --filter=STRING       Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')
+      --template=STRING     Go template for --format template, executed against the JSON envelope
+      --template-file=PATH  Read the --format template Go template from a file
+      --profile=STRING      Config profile for flag defaults ($CONFLUENCE_PROFILE)
 `
 }
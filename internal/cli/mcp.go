@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+const mcpLatestProtocolVersion = "2025-06-18"
+
+// mcpProtocolVersions are the MCP revisions the stdio server can speak.
+var mcpProtocolVersions = []string{"2024-11-05", "2025-03-26", mcpLatestProtocolVersion}
+
+// JSON-RPC 2.0 error codes used by the MCP server.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// McpCmd groups Model Context Protocol commands.
+type McpCmd struct {
+	Serve McpServeCmd `cmd:"" help:"Serve read commands as MCP tools over stdio"`
+}
+
+type McpServeCmd struct{}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type mcpInitializeParams struct {
+	ProtocolVersion string `json:"protocolVersion"`
+}
+
+type mcpInitializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ServerInfo      mcpServerInfo  `json:"serverInfo"`
+	Instructions    string         `json:"instructions,omitempty"`
+}
+
+type mcpServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func (cmd *McpServeCmd) Run(app *App) error {
+	return app.serveMCP(os.Stdin)
+}
+
+// serveMCP reads newline-delimited JSON-RPC messages until EOF and writes one
+// response line per request. Notifications get no response.
+func (app *App) serveMCP(r io.Reader) error {
+	tools, err := app.mcpTools()
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		response, ok := app.handleMCPMessage(tools, line)
+		if !ok {
+			continue
+		}
+		b, err := json.Marshal(response)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(app.Stdout, string(b)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (app *App) handleMCPMessage(tools []mcpTool, line []byte) (rpcResponse, bool) {
+	var request rpcRequest
+	if err := json.Unmarshal(line, &request); err != nil {
+		return rpcFailure(json.RawMessage("null"), rpcParseError, "parse error: "+err.Error()), true
+	}
+	notification := len(request.ID) == 0
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		if notification {
+			return rpcResponse{}, false
+		}
+		return rpcFailure(request.ID, rpcInvalidRequest, `invalid request: expected "jsonrpc":"2.0" and a method`), true
+	}
+	if notification {
+		return rpcResponse{}, false
+	}
+
+	switch request.Method {
+	case "initialize":
+		var params mcpInitializeParams
+		if len(request.Params) > 0 {
+			if err := json.Unmarshal(request.Params, &params); err != nil {
+				return rpcFailure(request.ID, rpcInvalidParams, "invalid initialize params: "+err.Error()), true
+			}
+		}
+		return rpcSuccess(request.ID, mcpInitializeResult{
+			ProtocolVersion: negotiateProtocolVersion(params.ProtocolVersion),
+			Capabilities:    map[string]any{"tools": map[string]any{}},
+			ServerInfo:      mcpServerInfo{Name: "confluence", Version: app.Version},
+			Instructions:    "Read-only Confluence tools. Results are the same JSON envelopes as the confluence CLI; follow page.nextCursor with the cursor argument.",
+		}), true
+	case "ping":
+		return rpcSuccess(request.ID, map[string]any{}), true
+	case "tools/list":
+		listed := make([]mcpToolInfo, 0, len(tools))
+		for _, tool := range tools {
+			listed = append(listed, tool.info())
+		}
+		return rpcSuccess(request.ID, map[string]any{"tools": listed}), true
+	case "tools/call":
+		var params mcpCallParams
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			return rpcFailure(request.ID, rpcInvalidParams, "invalid tools/call params: "+err.Error()), true
+		}
+		tool, ok := findMCPTool(tools, params.Name)
+		if !ok {
+			return rpcFailure(request.ID, rpcInvalidParams, fmt.Sprintf("unknown tool %q", params.Name)), true
+		}
+		return rpcSuccess(request.ID, app.callMCPTool(tool, params.Arguments)), true
+	default:
+		return rpcFailure(request.ID, rpcMethodNotFound, fmt.Sprintf("method %q not found", request.Method)), true
+	}
+}
+
+func negotiateProtocolVersion(requested string) string {
+	for _, version := range mcpProtocolVersions {
+		if version == requested {
+			return requested
+		}
+	}
+	return mcpLatestProtocolVersion
+}
+
+func rpcSuccess(id json.RawMessage, result any) rpcResponse {
+	return rpcResponse{JSONRPC: "2.0", ID: id, Result: result}
+}
+
+func rpcFailure(id json.RawMessage, code int, message string) rpcResponse {
+	return rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
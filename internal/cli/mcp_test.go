@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+)
+
+func newMCPTestApp(t *testing.T) (*App, *bytes.Buffer) {
+	t.Helper()
+	var cli CLI
+	parser, err := kong.New(&cli, kong.Name("confluence"))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	ctx, err := parser.Parse([]string{"mcp", "serve"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var stdout bytes.Buffer
+	return &App{Stdout: &stdout, Format: "json", Version: "test-version", config: &ConfigFile{}, kongCtx: ctx}, &stdout
+}
+
+func serveMCPLines(t *testing.T, app *App, stdout *bytes.Buffer, requests ...string) []map[string]any {
+	t.Helper()
+	if err := app.serveMCP(strings.NewReader(strings.Join(requests, "\n") + "\n")); err != nil {
+		t.Fatalf("serveMCP: %v", err)
+	}
+	var responses []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		var response map[string]any
+		if err := json.Unmarshal([]byte(line), &response); err != nil {
+			t.Fatalf("parse response %q: %v", line, err)
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func TestMCPToolsListDerivesInputSchemas(t *testing.T) {
+	app, stdout := newMCPTestApp(t)
+	responses := serveMCPLines(t, app, stdout, `{"jsonrpc":"2.0","id":"a","method":"tools/list"}`)
+
+	var result struct {
+		Tools []mcpToolInfo `json:"tools"`
+	}
+	b, _ := json.Marshal(responses[0]["result"])
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatalf("parse tools/list: %v", err)
+	}
+	var names []string
+	tools := map[string]mcpToolInfo{}
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+		tools[tool.Name] = tool
+	}
+	if want := []string{"spaces_list", "spaces_get", "pages_list", "pages_get", "pages_get_many", "pages_ancestors", "pages_descendants", "pages_tree", "pages_search", "search", "version"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("tools = %v, want %v", names, want)
+	}
+
+	get := tools["pages_get"].InputSchema
+	if !reflect.DeepEqual(get.Required, []string{"page_id"}) {
+		t.Errorf("pages_get required = %v", get.Required)
+	}
+	if format := get.Properties["body_format"]; format == nil || format.Type != "string" || format.Description == "" {
+		t.Errorf("pages_get body_format = %+v", format)
+	}
+	if fields := get.Properties["fields"]; fields == nil || fields.Type != "array" {
+		t.Errorf("pages_get fields = %+v", fields)
+	}
+	if limit := tools["spaces_list"].InputSchema.Properties["limit"]; limit == nil || limit.Type != "integer" || limit.Default == nil {
+		t.Errorf("spaces_list limit = %+v", limit)
+	}
+}
+
+func TestMCPProtocolErrors(t *testing.T) {
+	app, stdout := newMCPTestApp(t)
+	responses := serveMCPLines(t, app, stdout,
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"pages_delete","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"pages_get","arguments":{"page_id":123}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"pages_get","arguments":{}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/cancelled"}`,
+		`{"jsonrpc":"2.0","id":5,"method":"ping"}`,
+	)
+	if len(responses) != 6 {
+		t.Fatalf("expected 6 responses, got %d", len(responses))
+	}
+
+	for i, wantCode := range []float64{rpcParseError, rpcMethodNotFound, rpcInvalidParams} {
+		rpcErr, _ := responses[i]["error"].(map[string]any)
+		if rpcErr == nil || rpcErr["code"] != wantCode {
+			t.Errorf("response %d error = %v, want code %v", i, responses[i]["error"], wantCode)
+		}
+	}
+
+	for i, wantText := range []string{"expected a string", "--page-id"} {
+		result, _ := responses[3+i]["result"].(map[string]any)
+		content, _ := json.Marshal(result["content"])
+		if result["isError"] != true || !strings.Contains(string(content), `VALIDATION`) || !strings.Contains(string(content), wantText) {
+			t.Errorf("response %d = %v, want VALIDATION tool error mentioning %s", 3+i, result, wantText)
+		}
+	}
+
+	if result, ok := responses[5]["result"].(map[string]any); !ok || len(result) != 0 {
+		t.Errorf("ping result = %v", responses[5])
+	}
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+type mcpTool struct {
+	Name        string
+	Command     string
+	Description string
+	InputSchema *JSONSchema
+	flags       map[string]*kong.Flag
+}
+
+type mcpToolInfo struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	InputSchema *JSONSchema `json:"inputSchema"`
+}
+
+type mcpCallParams struct {
+	Name      string                     `json:"name"`
+	Arguments map[string]json.RawMessage `json:"arguments"`
+}
+
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type mcpCallResult struct {
+	Content           []mcpContent    `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError"`
+}
+
+func (tool mcpTool) info() mcpToolInfo {
+	return mcpToolInfo{Name: tool.Name, Description: tool.Description, InputSchema: tool.InputSchema}
+}
+
+func findMCPTool(tools []mcpTool, name string) (mcpTool, bool) {
+	for _, tool := range tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return mcpTool{}, false
+}
+
+// mcpTools exposes each read command from commandContracts as a tool and
+// derives its input schema from the command's kong flags, so tools and CLI
+// flags cannot drift apart.
+func (app *App) mcpTools() ([]mcpTool, error) {
+	commands := readCommands()
+	tools := make([]mcpTool, 0, len(commands))
+	for _, command := range commands {
+		name := mcpToolName(command)
+		node, flags, err := commandFlags(app.kongCtx.Model, command)
+		if err != nil {
+			return nil, fmt.Errorf("mcp tool %s: %w", name, err)
+		}
+		closed := false
+		tool := mcpTool{
+			Name:        name,
+			Command:     command,
+			Description: fmt.Sprintf("%s. Returns the JSON envelope of `confluence %s`.", node.Help, command),
+			InputSchema: &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}, AdditionalProperties: &closed},
+			flags:       map[string]*kong.Flag{},
+		}
+		for _, flag := range flags {
+			name := mcpArgumentName(flag.Name)
+			tool.flags[name] = flag
+			tool.InputSchema.Properties[name] = flagSchema(flag)
+			if flag.Required {
+				tool.InputSchema.Required = append(tool.InputSchema.Required, name)
+			}
+		}
+		tools = append(tools, tool)
+	}
+	return tools, nil
+}
+
+// mcpToolName turns "pages get-many" into "pages_get_many".
+func mcpToolName(command string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(command)
+}
+
+func mcpArgumentName(flag string) string {
+	return strings.ReplaceAll(flag, "-", "_")
+}
+
+func flagSchema(flag *kong.Flag) *JSONSchema {
+	schema := &JSONSchema{Description: flag.Help}
+	switch valueType(flag.Target.Type()) {
+	case "boolean":
+		schema.Type = "boolean"
+		if value, err := strconv.ParseBool(flag.Default); err == nil {
+			schema.Default = value
+		}
+	case "integer":
+		schema.Type = "integer"
+		if value, err := strconv.Atoi(flag.Default); err == nil {
+			schema.Default = value
+		}
+	case "string[]":
+		schema.Type = "array"
+		schema.Items = &JSONSchema{Type: "string"}
+	default:
+		schema.Type = "string"
+		if flag.Default != "" {
+			schema.Default = flag.Default
+		}
+	}
+	if flag.Enum != "" {
+		for _, value := range flag.EnumSlice() {
+			if value != "" {
+				schema.Enum = append(schema.Enum, value)
+			}
+		}
+	}
+	return schema
+}
+
+// commandArgs turns tool arguments back into the command line the tool runs.
+func (tool mcpTool) commandArgs(arguments map[string]json.RawMessage) ([]string, error) {
+	names := make([]string, 0, len(arguments))
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := strings.Fields(tool.Command)
+	for _, name := range names {
+		flag, ok := tool.flags[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument %q", name)
+		}
+		values, err := flagArgValues(flag, arguments[name])
+		if err != nil {
+			return nil, fmt.Errorf("argument %q: %w", name, err)
+		}
+		for _, value := range values {
+			args = append(args, "--"+flag.Name+"="+value)
+		}
+	}
+	return append(args, "--format=json"), nil
+}
+
+func (app *App) callMCPTool(tool mcpTool, arguments map[string]json.RawMessage) mcpCallResult {
+	args, err := tool.commandArgs(arguments)
+	if err != nil {
+		return mcpToolError(ErrorDetail{Code: "VALIDATION", Message: err.Error(), Hint: "Call tools/list for the " + tool.Name + " input schema."})
+	}
+	var stdout bytes.Buffer
+	if detail, code := app.runInProcess(args, &stdout); code != ExitOK {
+		return mcpToolError(detail)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, stdout.Bytes()); err != nil {
+		return mcpToolError(ErrorDetail{Code: "INTERNAL", Message: err.Error(), Hint: "Report the command output as a bug."})
+	}
+	return mcpCallResult{
+		Content:           []mcpContent{{Type: "text", Text: compact.String()}},
+		StructuredContent: compact.Bytes(),
+	}
+}
+
+// mcpToolError reports an ErrorEnvelope as a tool result so the model sees the
+// same code, message, and hint the CLI writes to stderr.
+func mcpToolError(detail ErrorDetail) mcpCallResult {
+	b, _ := json.Marshal(ErrorEnvelope{Error: detail})
+	return mcpCallResult{
+		Content:           []mcpContent{{Type: "text", Text: string(b)}},
+		StructuredContent: b,
+		IsError:           true,
+	}
+}
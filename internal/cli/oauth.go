@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+// OAuthCredentials holds an imported Atlassian OAuth 2.0 (3LO) grant.
+type OAuthCredentials struct {
+	CloudID      string     `json:"cloudId"`
+	AccessToken  string     `json:"accessToken"`
+	RefreshToken string     `json:"refreshToken,omitempty"`
+	ClientID     string     `json:"clientId,omitempty"`
+	ClientSecret string     `json:"clientSecret,omitempty"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+}
+
+func (o *OAuthCredentials) trim() {
+	o.CloudID = strings.TrimSpace(o.CloudID)
+	o.AccessToken = strings.TrimSpace(o.AccessToken)
+	o.RefreshToken = strings.TrimSpace(o.RefreshToken)
+	o.ClientID = strings.TrimSpace(o.ClientID)
+	o.ClientSecret = strings.TrimSpace(o.ClientSecret)
+}
+
+func validateOAuthCredentials(creds Credentials) error {
+	hint := helpHint("auth login")
+	if creds.Email != "" || creds.Token != "" {
+		return validationError("oauth credentials cannot be combined with email or token", hint)
+	}
+	if creds.flavor() != confluence.FlavorCloud {
+		return validationError("oauth credentials are only supported for --flavor cloud", hint)
+	}
+	if creds.OAuth.CloudID == "" || creds.OAuth.AccessToken == "" {
+		return validationError("oauth credentials require cloudId and accessToken", hint)
+	}
+	if creds.OAuth.RefreshToken != "" && (creds.OAuth.ClientID == "" || creds.OAuth.ClientSecret == "") {
+		return validationError("oauth refreshToken requires clientId and clientSecret", hint)
+	}
+	return nil
+}
+
+func oauthTokenURL() string {
+	if v := strings.TrimSpace(os.Getenv("CONFLUENCE_OAUTH_TOKEN_URL")); v != "" {
+		return v
+	}
+	return confluence.DefaultOAuth2TokenURL
+}
+
+func apiGatewayURL() string {
+	if v := strings.TrimSpace(os.Getenv("CONFLUENCE_API_GATEWAY_URL")); v != "" {
+		return v
+	}
+	return confluence.DefaultAPIGatewayURL
+}
+
+// newOAuthClient builds a gateway client whose rotated tokens are written back
+// to the credential store, because Atlassian invalidates the previous refresh
+// token. A failed save is a warning: the rotated token still serves this run.
+func newOAuthClient(creds Credentials, timeout time.Duration, stderr io.Writer) *confluence.Client {
+	oauth := *creds.OAuth
+	var expiresAt time.Time
+	if oauth.ExpiresAt != nil {
+		expiresAt = *oauth.ExpiresAt
+	}
+	auth := confluence.NewOAuth2Auth(confluence.OAuth2Options{
+		ClientID:     oauth.ClientID,
+		ClientSecret: oauth.ClientSecret,
+		TokenURL:     oauthTokenURL(),
+		HTTPClient:   &http.Client{Timeout: timeout},
+		Token: confluence.OAuth2Token{
+			AccessToken:  oauth.AccessToken,
+			RefreshToken: oauth.RefreshToken,
+			ExpiresAt:    expiresAt,
+		},
+		OnRefresh: func(token confluence.OAuth2Token) error {
+			oauth.AccessToken = token.AccessToken
+			oauth.RefreshToken = token.RefreshToken
+			oauth.ExpiresAt = optionalTime(token.ExpiresAt)
+			updated := creds
+			updated.OAuth = &oauth
+			if _, err := saveStoredCredentials(updated); err != nil {
+				writeWarning(stderr, "OAUTH_TOKEN_NOT_SAVED", "refreshed oauth token could not be saved: "+err.Error(), "The stored refresh token is now spent; rerun `confluence auth login` after this command.")
+				return err
+			}
+			return nil
+		},
+	})
+	return confluence.NewClient(confluence.Options{
+		CloudID:       oauth.CloudID,
+		APIGatewayURL: apiGatewayURL(),
+		Authenticator: auth,
+		Timeout:       timeout,
+	})
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+type PagesAncestorsCmd struct {
+	PageID string `help:"Page ID, page URL, tiny link, or SPACEKEY:Title" required:""`
+}
+
+func (cmd *PagesAncestorsCmd) Run(app *App) error {
+	ancestors, err := app.Client.GetPageAncestors(cmd.PageID)
+	if err != nil {
+		return err
+	}
+
+	items := newBreadcrumbs(ancestors)
+	if app.IsPlain() {
+		if len(items) == 0 {
+			discardWrite(fmt.Fprintln(app.Stdout, "(top level of its space)"))
+			return nil
+		}
+		for depth, item := range items {
+			discardWrite(fmt.Fprintf(app.Stdout, "%s%s (id:%s)\n", strings.Repeat("  ", depth), breadcrumbLabel(item), item.ID))
+		}
+		return nil
+	}
+	return app.renderEnvelope(listEnvelope(items, confluence.MaxAncestors, "", "page-ancestor", []string{"id", "type", "title"}))
+}
+
+// breadcrumbTrail joins ancestors root first, e.g. "Home > Decisions".
+func breadcrumbTrail(breadcrumbs []Breadcrumb) string {
+	labels := make([]string, len(breadcrumbs))
+	for i, crumb := range breadcrumbs {
+		labels[i] = breadcrumbLabel(crumb)
+	}
+	return strings.Join(labels, " > ")
+}
+
+// breadcrumbLabel falls back to the type for untitled ancestors such as
+// folders.
+func breadcrumbLabel(crumb Breadcrumb) string {
+	if crumb.Title != "" {
+		return crumb.Title
+	}
+	return "[" + crumb.Type + "]"
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPagesAncestorsAndBreadcrumb(t *testing.T) {
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/pages/400/ancestors"):
+			_, _ = w.Write([]byte(`{"results":[{"id":"1","type":"page"},{"id":"9","type":"folder"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/pages/400"):
+			_, _ = w.Write([]byte(`{"id":"400","title":"ADR 12","spaceId":"S1","status":"current","parentId":"9"}`))
+		case strings.HasSuffix(r.URL.Path, "/pages"):
+			_, _ = w.Write([]byte(`{"results":[{"id":"1","title":"Home"}],"_links":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	var stdout bytes.Buffer
+	detail, code := app.runInProcess([]string{"pages", "ancestors", "--page-id", "400", "--format=json"}, &stdout)
+	if code != ExitOK {
+		t.Fatalf("ancestors = %d %+v", code, detail)
+	}
+	for _, want := range []string{`"title": "Home"`, `"type": "folder"`, `"itemType": "page-ancestor"`, `"limit": 250`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("ancestors output missing %s:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if detail, code := app.runInProcess([]string{"pages", "ancestors", "--page-id", "400", "--format=plain"}, &stdout); code != ExitOK {
+		t.Fatalf("plain ancestors = %d %+v", code, detail)
+	}
+	if want := "Home (id:1)\n  [folder] (id:9)\n"; stdout.String() != want {
+		t.Errorf("plain ancestors = %q, want %q", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	if detail, code := app.runInProcess([]string{"pages", "get", "--page-id", "400", "--breadcrumb", "--format=plain"}, &stdout); code != ExitOK {
+		t.Fatalf("pages get --breadcrumb = %d %+v", code, detail)
+	}
+	if !strings.Contains(stdout.String(), "Breadcrumb: Home > [folder]\n") {
+		t.Errorf("plain page missing breadcrumb:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if detail, code := app.runInProcess([]string{"pages", "get", "--page-id", "400", "--format=json"}, &stdout); code != ExitOK {
+		t.Fatalf("pages get = %d %+v", code, detail)
+	}
+	if strings.Contains(stdout.String(), "breadcrumb") {
+		t.Errorf("breadcrumb present without --breadcrumb:\n%s", stdout.String())
+	}
+}
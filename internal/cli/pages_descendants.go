@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+type PagesDescendantsCmd struct {
+	PageID string `help:"Root page ID, page URL, tiny link, or SPACEKEY:Title" required:""`
+	Depth  int    `help:"Maximum levels below the page, or 0 for no limit" default:"5"`
+	Limit  int    `help:"Maximum number of results per page" default:"10"`
+	Cursor string `help:"Opaque cursor from the previous response"`
+	All    bool   `help:"Follow nextCursor through every remaining page"`
+}
+
+// DescendantSummary is the CLI-owned flat descendants shape.
+type DescendantSummary struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	ParentID string `json:"parentId"`
+	Depth    int    `json:"depth"`
+}
+
+func (cmd *PagesDescendantsCmd) Run(app *App) error {
+	hint := helpHint("pages descendants")
+	if err := validateRange("depth", cmd.Depth, 0, confluence.MaxDescendantDepth, hint); err != nil {
+		return err
+	}
+	if err := validateRange("limit", cmd.Limit, 1, maxListLimit, hint); err != nil {
+		return err
+	}
+
+	list := listPages[DescendantSummary]{Limit: cmd.Limit, Cursor: cmd.Cursor, All: cmd.All, ItemType: "page-descendant", Fields: []string{"id", "title", "type", "status", "parentId", "depth"}, Plain: renderDescendantsPlain}
+	return renderListPages(app, list, func(cursor string) ([]DescendantSummary, string, error) {
+		result, err := app.Client.GetPageDescendants(confluence.GetPageDescendantsOptions{
+			PageID: cmd.PageID,
+			Depth:  cmd.Depth,
+			Limit:  cmd.Limit,
+			Cursor: cursor,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		items := make([]DescendantSummary, len(result.Results))
+		for i, descendant := range result.Results {
+			items[i] = DescendantSummary{
+				ID:       descendant.ID,
+				Title:    descendant.Title,
+				Type:     descendant.Type,
+				Status:   descendant.Status,
+				ParentID: descendant.ParentID,
+				Depth:    descendant.Depth,
+			}
+		}
+		return items, result.NextCursor, nil
+	})
+}
+
+func renderDescendantsPlain(w io.Writer, items []DescendantSummary, nextCursor string) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	discardWrite(fmt.Fprintln(tw, "DEPTH\tID\tPARENT ID\tTITLE"))
+	for _, item := range items {
+		indent := strings.Repeat("  ", max(item.Depth-1, 0))
+		discardWrite(fmt.Fprintf(tw, "%d\t%s\t%s\t%s%s\n", item.Depth, item.ID, item.ParentID, indent, item.Title))
+	}
+	_ = tw.Flush()
+	if nextCursor != "" {
+		discardWrite(fmt.Fprintf(w, "\nNext cursor: %s\n", nextCursor))
+	}
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPagesDescendantsFlatList(t *testing.T) {
+	var gotQuery string
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, "/pages/100/descendants") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"200","type":"page","title":"Guides","status":"current","parentId":"100","depth":1},
+			{"id":"300","type":"page","title":"Setup","status":"current","parentId":"200","depth":2}
+		],"_links":{"next":"/wiki/api/v2/pages/100/descendants?cursor=abc"}}`))
+	})
+
+	var stdout bytes.Buffer
+	detail, code := app.runInProcess([]string{"pages", "descendants", "--page-id", "100", "--depth", "2", "--format=json"}, &stdout)
+	if code != ExitOK {
+		t.Fatalf("descendants = %d %+v", code, detail)
+	}
+	if !strings.Contains(gotQuery, "depth=2") {
+		t.Errorf("query = %q, want depth=2", gotQuery)
+	}
+	for _, want := range []string{`"parentId": "200"`, `"depth": 2`, `"itemType": "page-descendant"`, `"nextCursor": "abc"`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("descendants output missing %s:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if detail, code := app.runInProcess([]string{"pages", "descendants", "--page-id", "100", "--format=plain"}, &stdout); code != ExitOK {
+		t.Fatalf("plain descendants = %d %+v", code, detail)
+	}
+	if !strings.Contains(stdout.String(), "100        Guides\n") || !strings.Contains(stdout.String(), "200          Setup\n") {
+		t.Errorf("plain descendants missing indented child:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	gotQuery = ""
+	if detail, code := app.runInProcess([]string{"pages", "descendants", "--page-id", "100", "--depth", "0"}, &stdout); code != ExitOK {
+		t.Fatalf("--depth 0 = %d %+v", code, detail)
+	}
+	if strings.Contains(gotQuery, "depth=") {
+		t.Errorf("--depth 0 query = %q, want no depth", gotQuery)
+	}
+
+	stdout.Reset()
+	if _, code := app.runInProcess([]string{"pages", "descendants", "--page-id", "100", "--depth", "6"}, &stdout); code != ExitValidation {
+		t.Errorf("--depth 6 exit = %d, want %d", code, ExitValidation)
+	}
+}
@@ -3,13 +3,14 @@ package cli
 import confluence "github.com/Prisma-Labs-Dev/confluence-cli"
 
 type PagesGetCmd struct {
-	PageID     string `help:"Page ID from list/search output" required:""`
+	PageID     string `help:"Page ID, page URL, tiny link, or SPACEKEY:Title" required:""`
 	BodyFormat string `name:"body-format" help:"Optional body format"`
+	Breadcrumb bool   `help:"Include the ancestor chain, root first, as breadcrumb"`
 }
 
 func (cmd *PagesGetCmd) Run(app *App) error {
-	if cmd.BodyFormat != "" && cmd.BodyFormat != "view" && cmd.BodyFormat != "storage" && cmd.BodyFormat != "atlas_doc_format" {
-		return validationError("body-format must be one of: view, storage, atlas_doc_format", helpHint("pages get"))
+	if err := validateBodyFormat(cmd.BodyFormat, app.Flavor, "pages get"); err != nil {
+		return err
 	}
 
 	page, err := app.Client.GetPage(confluence.GetPageOptions{
@@ -21,14 +22,37 @@ func (cmd *PagesGetCmd) Run(app *App) error {
 	}
 
 	item := newPageDetail(page, cmd.BodyFormat)
+	fields := pageDetailFields(cmd.BodyFormat)
+	if cmd.Breadcrumb {
+		ancestors, err := app.Client.GetPageAncestors(page.ID)
+		if err != nil {
+			return err
+		}
+		item.Breadcrumb = newBreadcrumbs(ancestors)
+		fields = append(fields, "breadcrumb")
+	}
 	if app.IsPlain() {
 		renderPagePlain(app.Stdout, item)
 		return nil
 	}
 
+	return app.renderEnvelope(itemEnvelope(item, "page-detail", fields))
+}
+
+func validateBodyFormat(bodyFormat, flavor, command string) error {
+	if bodyFormat != "" && bodyFormat != "view" && bodyFormat != "storage" && bodyFormat != "atlas_doc_format" {
+		return validationError("body-format must be one of: view, storage, atlas_doc_format", helpHint(command))
+	}
+	if bodyFormat == "atlas_doc_format" && flavor == confluence.FlavorServer {
+		return validationError("body-format atlas_doc_format is not available with --flavor server; use view or storage", helpHint(command))
+	}
+	return nil
+}
+
+func pageDetailFields(bodyFormat string) []string {
 	fields := []string{"id", "title", "spaceId", "status", "parentId", "parentType", "authorId", "createdAt", "version"}
-	if cmd.BodyFormat != "" {
+	if bodyFormat != "" {
 		fields = append(fields, "body")
 	}
-	return renderJSON(app.Stdout, itemEnvelope(item, "page-detail", fields))
+	return fields
 }
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+const maxGetManyIDs = 250
+
+type PagesGetManyCmd struct {
+	PageID     []string `name:"page-id" help:"Comma-separated page IDs or URLs; omit to read them from piped stdin" placeholder:"ID,..."`
+	BodyFormat string   `name:"body-format" help:"Optional body format"`
+}
+
+func (cmd *PagesGetManyCmd) Run(app *App) error {
+	if err := validateBodyFormat(cmd.BodyFormat, app.Flavor, "pages get-many"); err != nil {
+		return err
+	}
+	ids, err := cmd.pageIDs(app)
+	if err != nil {
+		return err
+	}
+
+	result, err := app.Client.GetPages(confluence.GetPagesOptions{
+		PageIDs:    ids,
+		BodyFormat: cmd.BodyFormat,
+	})
+	if err != nil {
+		return err
+	}
+
+	items := make([]PageDetail, 0, len(result.Pages))
+	for i := range result.Pages {
+		items = append(items, newPageDetail(&result.Pages[i], cmd.BodyFormat))
+	}
+	if app.IsPlain() {
+		for i, item := range items {
+			if i > 0 {
+				discardWrite(fmt.Fprintln(app.Stdout, "\n---"))
+			}
+			renderPagePlain(app.Stdout, item)
+		}
+		if len(result.NotFound) > 0 {
+			discardWrite(fmt.Fprintf(app.Stdout, "\nNot found: %s\n", strings.Join(result.NotFound, ", ")))
+		}
+		return nil
+	}
+
+	envelope := listEnvelope(items, len(ids), "", "page-detail", pageDetailFields(cmd.BodyFormat))
+	envelope.NotFound = result.NotFound
+	return app.renderEnvelope(envelope)
+}
+
+// pageIDs reads IDs from --page-id, or from piped stdin separated by
+// whitespace or commas. Stdin entries may be URLs or tiny links like
+// --page-id values.
+func (cmd *PagesGetManyCmd) pageIDs(app *App) ([]string, error) {
+	ids := cmd.PageID
+	if len(ids) == 0 {
+		if app.inProcess || isTerminal(int(os.Stdin.Fd())) {
+			return nil, validationError("provide --page-id or pipe page IDs on stdin", helpHint("pages get-many"))
+		}
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("read page IDs from stdin: %w", err)
+		}
+		ids = strings.FieldsFunc(string(input), func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		})
+	}
+
+	var cleaned []string
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			cleaned = append(cleaned, id)
+		}
+	}
+	if len(cleaned) > maxGetManyIDs {
+		return nil, validationErrorf(helpHint("pages get-many"), "at most %d page IDs per call, got %d", maxGetManyIDs, len(cleaned))
+	}
+	if len(cmd.PageID) == 0 {
+		for i, id := range cleaned {
+			resolved, err := app.resolveReference("page-id", id)
+			if err != nil {
+				return nil, err
+			}
+			cleaned[i] = resolved
+		}
+	}
+	if len(cleaned) == 0 {
+		return nil, validationError("no page IDs given", helpHint("pages get-many"))
+	}
+	return uniqueIDs(cleaned), nil
+}
+
+// uniqueIDs drops repeated IDs, keeping the first of each, so page.limit
+// equals results plus notFound.
+func uniqueIDs(ids []string) []string {
+	seen := map[string]bool{}
+	unique := ids[:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -1,37 +1,103 @@
 package cli
 
-import confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+import (
+	"strconv"
+	"time"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+// pageStatuses are the v2 /pages status values; drafts are not listable.
+var pageStatuses = []string{"current", "archived", "trashed"}
 
 type PagesListCmd struct {
-	SpaceID string `help:"Space ID from spaces list output" required:""`
-	Limit   int    `help:"Maximum number of results per page" default:"10"`
-	Cursor  string `help:"Opaque cursor from the previous response"`
-	Sort    string `help:"Sort order: title, created-date, or -modified-date"`
+	SpaceID        string `help:"Space ID from spaces list output, or a space URL" exclusive:"space"`
+	SpaceKey       string `help:"Space key such as ENG, instead of --space-id" exclusive:"space"`
+	Limit          int    `help:"Maximum number of results per page" default:"10"`
+	Cursor         string `help:"Opaque cursor from the previous response"`
+	Sort           string `help:"Sort order: title, created-date, or -modified-date"`
+	Status         string `help:"Only pages with this status: current, archived, or trashed"`
+	Title          string `help:"Only pages with exactly this title"`
+	CreatedAfter   string `help:"Only pages created on or after this date (YYYY-MM-DD)" placeholder:"DATE"`
+	CreatedBefore  string `help:"Only pages created before this date (YYYY-MM-DD)" placeholder:"DATE"`
+	ModifiedAfter  string `help:"Only pages modified on or after this date (YYYY-MM-DD)" placeholder:"DATE"`
+	ModifiedBefore string `help:"Only pages modified before this date (YYYY-MM-DD)" placeholder:"DATE"`
+	All            bool   `help:"Follow nextCursor through every remaining page"`
 }
 
 func (cmd *PagesListCmd) Run(app *App) error {
-	if err := validateRange("limit", cmd.Limit, 1, maxListLimit, helpHint("pages list")); err != nil {
+	hint := helpHint("pages list")
+	if err := validateRange("limit", cmd.Limit, 1, maxListLimit, hint); err != nil {
 		return err
 	}
-
-	result, err := app.Client.ListPages(confluence.ListPagesOptions{
-		SpaceID: cmd.SpaceID,
-		Limit:   cmd.Limit,
-		Cursor:  cmd.Cursor,
-		Sort:    cmd.Sort,
-	})
-	if err != nil {
+	if err := validateEnum("status", cmd.Status, pageStatuses, hint); err != nil {
 		return err
 	}
-
-	items := make([]PageSummary, len(result.Results))
-	for i, page := range result.Results {
-		items[i] = newPageSummary(page)
+	opts := confluence.ListPagesOptions{Status: cmd.Status, Title: cmd.Title, Limit: cmd.Limit, Cursor: cmd.Cursor, Sort: cmd.Sort}
+	for _, date := range []struct {
+		name   string
+		value  string
+		target *time.Time
+	}{
+		{"created-after", cmd.CreatedAfter, &opts.CreatedAfter},
+		{"created-before", cmd.CreatedBefore, &opts.CreatedBefore},
+		{"modified-after", cmd.ModifiedAfter, &opts.ModifiedAfter},
+		{"modified-before", cmd.ModifiedBefore, &opts.ModifiedBefore},
+	} {
+		parsed, err := parseDateFlag(date.name, date.value, hint)
+		if err != nil {
+			return err
+		}
+		*date.target = parsed
 	}
-
-	if app.IsPlain() {
-		renderPagesPlain(app.Stdout, items, result.NextCursor)
-		return nil
+	dated := cmd.CreatedAfter != "" || cmd.CreatedBefore != "" || cmd.ModifiedAfter != "" || cmd.ModifiedBefore != ""
+	if opts.Status != "" && opts.Status != "current" {
+		if app.Flavor == confluence.FlavorServer {
+			return validationErrorf(hint, "status %s is not available with --flavor server; Data Center lists current pages only", opts.Status)
+		}
+		if dated {
+			return validationErrorf(hint, "status %s cannot be combined with date filters; date filters search current pages only", opts.Status)
+		}
 	}
-	return renderJSON(app.Stdout, listEnvelope(items, cmd.Limit, result.NextCursor, "page-summary", []string{"id", "title", "spaceId", "status", "parentId", "versionNumber"}))
+	if (cmd.SpaceID == "") == (cmd.SpaceKey == "") {
+		return validationError("provide exactly one of --space-id or --space-key", hint)
+	}
+	if cmd.SpaceID != "" {
+		if _, err := strconv.ParseUint(cmd.SpaceID, 10, 64); err != nil {
+			return validationErrorf(hint, "--space-id must be a numeric space ID, got %q", cmd.SpaceID)
+		}
+	}
+	// Date filters, and every Data Center listing, go through CQL, which
+	// cannot sort by id.
+	if dated || app.Flavor == confluence.FlavorServer {
+		if err := validateEnum("sort", cmd.Sort, confluence.CQLPageSorts, hint); err != nil {
+			return err
+		}
+	}
+	if cmd.SpaceKey != "" {
+		id, err := app.Client.FindSpaceID(cmd.SpaceKey)
+		if err != nil {
+			return err
+		}
+		if id == "" {
+			return validationErrorf(hint, "--space-key: no space with key %s", cmd.SpaceKey)
+		}
+		app.resolved = append(app.resolved, ResolvedRef{Flag: "space-key", Input: cmd.SpaceKey, ID: id})
+		cmd.SpaceID = id
+	}
+
+	opts.SpaceID = cmd.SpaceID
+	list := listPages[PageSummary]{Limit: cmd.Limit, Cursor: cmd.Cursor, All: cmd.All, ItemType: "page-summary", Fields: []string{"id", "title", "spaceId", "status", "parentId", "versionNumber"}, Plain: renderPagesPlain}
+	return renderListPages(app, list, func(cursor string) ([]PageSummary, string, error) {
+		opts.Cursor = cursor
+		result, err := app.Client.ListPages(opts)
+		if err != nil {
+			return nil, "", err
+		}
+		items := make([]PageSummary, len(result.Results))
+		for i, page := range result.Results {
+			items[i] = newPageSummary(page)
+		}
+		return items, result.NextCursor, nil
+	})
 }
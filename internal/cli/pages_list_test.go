@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPagesListValidatesFilters(t *testing.T) {
+	t.Setenv("CONFLUENCE_CONFIG_DIR", t.TempDir())
+	creds := []string{"--url", "https://example.atlassian.net", "--email", "a@b.com", "--token", "tok"}
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"pages", "list", "--space-id", "1", "--status", "deleted"}, want: "status must be one of: current, archived, trashed"},
+		{args: []string{"pages", "list", "--space-id", "1", "--status", "draft"}, want: "status must be one of: current, archived, trashed"},
+		{args: []string{"pages", "list", "--space-id", "1", "--created-after", "01/02/2024"}, want: "created-after must be a date like 2024-01-31"},
+		{args: []string{"pages", "list", "--space-id", "1", "--status", "archived", "--modified-before", "2024-01-01"}, want: "cannot be combined with date filters"},
+		{args: []string{"--flavor", "server", "pages", "list", "--space-id", "1", "--status", "archived"}, want: "not available with --flavor server"},
+		{args: []string{"pages", "list", "--space-id", "ENG"}, want: `--space-id must be a numeric space ID, got \"ENG\"`},
+		{args: []string{"pages", "list", "--space-id", "1", "--sort=-id", "--created-after", "2024-01-01"}, want: "sort must be one of: title, -title, created-date"},
+		{args: []string{"--flavor", "server", "pages", "list", "--space-id", "1", "--sort", "id"}, want: "sort must be one of: title, -title, created-date"},
+	}
+	for _, tc := range tests {
+		_, stderr, code := runCLIForTest(t, append(append([]string{}, creds...), tc.args...), false)
+		if code != ExitValidation || !strings.Contains(stderr, tc.want) {
+			t.Errorf("%v: exit=%d stderr=%s, want %s", tc.args, code, stderr, tc.want)
+		}
+	}
+}
@@ -1,60 +1,128 @@
 package cli
 
-import confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+import (
+	"time"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+var searchOrderBy = []string{"created", "-created", "lastmodified", "-lastmodified", "title", "-title"}
 
 type PagesSearchCmd struct {
-	Query     string `help:"Search text to match in page content or titles"`
-	CQL       string `help:"Raw CQL expression for advanced search"`
-	TitleOnly bool   `help:"Restrict matching to page titles (query mode only)"`
-	SpaceID   string `help:"Optional space ID filter (query mode only)"`
-	SpaceKey  string `help:"Optional space key filter such as SC or TNLTA (query mode only)"`
-	Limit     int    `help:"Maximum number of results per page" default:"10"`
-	Cursor    string `help:"Opaque cursor from the previous response"`
+	Query          string   `help:"Search text to match in page content or titles" exclusive:"mode"`
+	CQL            string   `help:"Raw CQL expression for advanced search" exclusive:"mode"`
+	TitleOnly      bool     `help:"Restrict matching to page titles (query mode only)"`
+	SpaceID        string   `help:"Optional space ID or space URL filter (query mode only)" exclusive:"space"`
+	SpaceKey       []string `help:"Optional space key filter such as SC or TNLTA; repeat for any of several (query mode only)" exclusive:"space" placeholder:"KEY,..."`
+	Label          []string `help:"Only pages with this label; repeat to require several" placeholder:"LABEL,..."`
+	Creator        string   `help:"Only pages created by this account ID, or me"`
+	Contributor    string   `help:"Only pages edited by this account ID, or me"`
+	Ancestor       string   `help:"Only pages below this page ID or page URL"`
+	CreatedAfter   string   `help:"Only pages created on or after this date (YYYY-MM-DD)" placeholder:"DATE"`
+	CreatedBefore  string   `help:"Only pages created before this date (YYYY-MM-DD)" placeholder:"DATE"`
+	ModifiedAfter  string   `help:"Only pages modified on or after this date (YYYY-MM-DD)" placeholder:"DATE"`
+	ModifiedBefore string   `help:"Only pages modified before this date (YYYY-MM-DD)" placeholder:"DATE"`
+	OrderBy        string   `help:"Order by created, lastmodified, or title; prefix - for descending"`
+	With           []string `help:"Add breadcrumbs, lastModified, or space (key and name) to each result" placeholder:"FIELD,..."`
+	Limit          int      `help:"Maximum number of results per page" default:"10"`
+	Cursor         string   `help:"Opaque cursor from the previous response"`
+	All            bool     `help:"Follow nextCursor through every remaining page"`
 }
 
 func (cmd *PagesSearchCmd) Run(app *App) error {
-	if err := validateRange("limit", cmd.Limit, 1, maxListLimit, helpHint("pages search")); err != nil {
+	hint := helpHint("pages search")
+	if err := validateRange("limit", cmd.Limit, 1, maxListLimit, hint); err != nil {
+		return err
+	}
+	if err := validateEnum("order-by", cmd.OrderBy, searchOrderBy, hint); err != nil {
 		return err
 	}
-	if cmd.Query == "" && cmd.CQL == "" {
-		return validationError("provide exactly one of --query or --cql", helpHint("pages search"))
+	var with []string
+	seen := map[string]bool{}
+	for _, enrichment := range cmd.With {
+		if err := validateEnum("with", enrichment, searchWith, hint); err != nil {
+			return err
+		}
+		if !seen[enrichment] {
+			seen[enrichment] = true
+			with = append(with, enrichment)
+		}
+	}
+	opts := confluence.PageSearchOptions{
+		Query:       cmd.Query,
+		CQL:         cmd.CQL,
+		TitleOnly:   cmd.TitleOnly,
+		SpaceID:     cmd.SpaceID,
+		SpaceKeys:   cmd.SpaceKey,
+		Labels:      cmd.Label,
+		Creator:     cmd.Creator,
+		Contributor: cmd.Contributor,
+		Ancestor:    cmd.Ancestor,
+		OrderBy:     cmd.OrderBy,
+		Limit:       cmd.Limit,
+		Cursor:      cmd.Cursor,
+	}
+	for _, enrichment := range with {
+		opts.WithSpace = opts.WithSpace || enrichment == "space"
+		opts.WithAncestors = opts.WithAncestors || enrichment == "breadcrumbs"
+	}
+	for _, date := range []struct {
+		name   string
+		value  string
+		target *time.Time
+	}{
+		{"created-after", cmd.CreatedAfter, &opts.CreatedAfter},
+		{"created-before", cmd.CreatedBefore, &opts.CreatedBefore},
+		{"modified-after", cmd.ModifiedAfter, &opts.ModifiedAfter},
+		{"modified-before", cmd.ModifiedBefore, &opts.ModifiedBefore},
+	} {
+		parsed, err := parseDateFlag(date.name, date.value, hint)
+		if err != nil {
+			return err
+		}
+		*date.target = parsed
 	}
+
+	filtered := len(cmd.Label) > 0 || cmd.Creator != "" || cmd.Contributor != "" || cmd.Ancestor != "" ||
+		cmd.CreatedAfter != "" || cmd.CreatedBefore != "" || cmd.ModifiedAfter != "" || cmd.ModifiedBefore != "" || cmd.OrderBy != ""
 	if cmd.Query != "" && cmd.CQL != "" {
-		return validationError("provide exactly one of --query or --cql", helpHint("pages search"))
+		return validationError("provide exactly one of --query or --cql", hint)
+	}
+	if cmd.Query == "" && cmd.CQL == "" && !filtered {
+		return validationError("provide exactly one of --query or --cql, or at least one structured filter", hint)
 	}
 	if cmd.CQL != "" {
 		if cmd.TitleOnly {
-			return validationError("--title-only cannot be combined with --cql", helpHint("pages search"))
+			return validationError("--title-only cannot be combined with --cql", hint)
 		}
-		if cmd.SpaceID != "" || cmd.SpaceKey != "" {
-			return validationError("--space-id and --space-key cannot be combined with --cql", helpHint("pages search"))
+		if cmd.SpaceID != "" || len(cmd.SpaceKey) > 0 {
+			return validationError("--space-id and --space-key cannot be combined with --cql", hint)
+		}
+		if filtered {
+			return validationError("structured filters cannot be combined with --cql; add them to the CQL instead", hint)
+		}
+		if err := checkCQL(app, cmd.CQL); err != nil {
+			return err
 		}
 	}
-	if cmd.SpaceID != "" && cmd.SpaceKey != "" {
-		return validationError("provide at most one of --space-id or --space-key", helpHint("pages search"))
+	if cmd.TitleOnly && cmd.Query == "" {
+		return validationError("--title-only requires --query", hint)
 	}
-
-	result, err := app.Client.SearchPages(confluence.PageSearchOptions{
-		Query:     cmd.Query,
-		CQL:       cmd.CQL,
-		TitleOnly: cmd.TitleOnly,
-		SpaceID:   cmd.SpaceID,
-		SpaceKey:  cmd.SpaceKey,
-		Limit:     cmd.Limit,
-		Cursor:    cmd.Cursor,
-	})
-	if err != nil {
-		return err
+	if cmd.SpaceID != "" && len(cmd.SpaceKey) > 0 {
+		return validationError("provide at most one of --space-id or --space-key", hint)
 	}
 
-	items := make([]SearchSummary, len(result.Results))
-	for i, resultItem := range result.Results {
-		items[i] = newSearchSummary(resultItem)
-	}
-
-	if app.IsPlain() {
-		renderSearchPlain(app.Stdout, items, result.NextCursor)
-		return nil
-	}
-	return renderJSON(app.Stdout, listEnvelope(items, cmd.Limit, result.NextCursor, "page-search-result", []string{"id", "title", "spaceId", "excerpt", "url"}))
+	list := listPages[SearchSummary]{Limit: cmd.Limit, Cursor: cmd.Cursor, All: cmd.All, ItemType: "page-search-result", Fields: searchSummaryFields(with), Plain: renderSearchPlain}
+	return renderListPages(app, list, func(cursor string) ([]SearchSummary, string, error) {
+		opts.Cursor = cursor
+		result, err := app.Client.SearchPages(opts)
+		if err != nil {
+			return nil, "", err
+		}
+		items := make([]SearchSummary, len(result.Results))
+		for i, resultItem := range result.Results {
+			items[i] = newSearchSummary(resultItem, with)
+		}
+		return items, result.NextCursor, nil
+	})
 }
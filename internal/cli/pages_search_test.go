@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPagesSearchStructuredFilters(t *testing.T) {
+	var gotCQL string
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		gotCQL = r.URL.Query().Get("cql")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[{"content":{"id":"9","type":"page","title":"Rollback"}}],"_links":{}}`))
+	})
+
+	var stdout bytes.Buffer
+	detail, code := app.runInProcess([]string{
+		"pages", "search", "--label", "runbook", "--space-key", "ENG", "--space-key", "OPS",
+		"--ancestor", "https://example.atlassian.net/wiki/spaces/ENG/pages/67890/Runbooks",
+		"--modified-after", "2024-06-01", "--order-by=-lastmodified", "--format=json",
+	}, &stdout)
+	if code != ExitOK {
+		t.Fatalf("runInProcess = %d %+v", code, detail)
+	}
+	want := `type=page AND space in ("ENG","OPS") AND label="runbook" AND ancestor=67890 AND lastmodified >= "2024-06-01" ORDER BY lastmodified desc`
+	if gotCQL != want {
+		t.Errorf("cql = %q, want %q", gotCQL, want)
+	}
+	if !strings.Contains(stdout.String(), `"flag": "ancestor"`) {
+		t.Errorf("resolved ancestor not echoed:\n%s", stdout.String())
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"pages", "search"}, want: "at least one structured filter"},
+		{args: []string{"pages", "search", "--cql", "type=page", "--label", "x"}, want: "cannot be combined with --cql"},
+		{args: []string{"pages", "search", "--label", "x", "--title-only"}, want: "--title-only requires --query"},
+		{args: []string{"pages", "search", "--query", "x", "--order-by", "modified"}, want: "order-by must be one of"},
+	}
+	for _, tc := range tests {
+		detail, code := app.runInProcess(append(tc.args, "--format=json"), &stdout)
+		if code != ExitValidation || !strings.Contains(detail.Message, tc.want) {
+			t.Errorf("%v = %d %+v, want %s", tc.args, code, detail, tc.want)
+		}
+	}
+}
+
+func TestPagesSearchWithEnrichments(t *testing.T) {
+	var gotExpand string
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		gotExpand = r.URL.Query().Get("expand")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[{"content":{"id":"400","type":"page","title":"ADR 12",
+			"space":{"id":98305,"key":"ENG","name":"Engineering"},"ancestors":[{"id":"1","type":"page","title":"Home"}]},
+			"lastModified":"2024-06-01T10:00:00.000Z"}],"_links":{}}`))
+	})
+
+	var stdout bytes.Buffer
+	detail, code := app.runInProcess([]string{"pages", "search", "--query", "ADR", "--with", "breadcrumbs,lastModified,space", "--format=json"}, &stdout)
+	if code != ExitOK {
+		t.Fatalf("runInProcess = %d %+v", code, detail)
+	}
+	if gotExpand != "content.space,content.ancestors" {
+		t.Errorf("expand = %q", gotExpand)
+	}
+	for _, want := range []string{`"spaceKey": "ENG"`, `"spaceName": "Engineering"`, `"lastModified": "2024-06-01T10:00:00Z"`, `"breadcrumbs": [`, `"title": "Home"`, `"breadcrumbs",`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %s:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if detail, code := app.runInProcess([]string{"pages", "search", "--query", "ADR", "--format=json"}, &stdout); code != ExitOK {
+		t.Fatalf("runInProcess = %d %+v", code, detail)
+	}
+	if gotExpand != "" || strings.Contains(stdout.String(), "lastModified") || strings.Contains(stdout.String(), "spaceKey") {
+		t.Errorf("unrequested enrichment: expand %q\n%s", gotExpand, stdout.String())
+	}
+
+	detail, code = app.runInProcess([]string{"pages", "search", "--query", "ADR", "--with", "ancestors", "--format=json"}, &stdout)
+	if code != ExitValidation || !strings.Contains(detail.Message, "with must be one of: breadcrumbs, lastModified, space") {
+		t.Errorf("bad --with = %d %+v", code, detail)
+	}
+}
@@ -3,7 +3,7 @@ package cli
 import confluence "github.com/Prisma-Labs-Dev/confluence-cli"
 
 type PagesTreeCmd struct {
-	PageID        string `help:"Root page ID" required:""`
+	PageID        string `help:"Root page ID, page URL, tiny link, or SPACEKEY:Title" required:""`
 	Depth         int    `help:"Maximum traversal depth" default:"1"`
 	LimitPerLevel int    `name:"limit-per-level" help:"Maximum children fetched per node" default:"10"`
 }
@@ -32,7 +32,7 @@ func (cmd *PagesTreeCmd) Run(app *App) error {
 		renderTreePlain(app.Stdout, tree)
 		return nil
 	}
-	return renderJSON(app.Stdout, itemEnvelope(tree, "page-tree", []string{"rootPageId", "depth", "limitPerLevel", "hasMoreChildren", "children"}))
+	return app.renderEnvelope(itemEnvelope(tree, "page-tree", []string{"rootPageId", "depth", "limitPerLevel", "hasMoreChildren", "children"}))
 }
 
 func buildPageTree(client *confluence.Client, pageID string, depth, limitPerLevel int) ([]PageTreeNode, bool, error) {
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+)
+
+// fetchPage reads the page of results at cursor and returns the cursor of the
+// page after it, or "" on the last page.
+type fetchPage[T any] func(cursor string) (items []T, nextCursor string, err error)
+
+// listPages describes how a list command renders its rows.
+type listPages[T any] struct {
+	Limit    int
+	Cursor   string
+	All      bool
+	ItemType string
+	Fields   []string
+	Plain    func(w io.Writer, results []T, nextCursor string)
+}
+
+// renderListPages fetches the page at list.Cursor, and with --all every page
+// after it, then renders the rows. ndjson writes each page's rows as soon as
+// that page arrives and the trailer after the last one; every other format
+// renders one envelope once the last page is in.
+func renderListPages[T any](app *App, list listPages[T], fetch fetchPage[T]) error {
+	cursor := list.Cursor
+	results := []T{}
+	for {
+		items, next, err := fetch(cursor)
+		if err != nil {
+			return err
+		}
+		last := !list.All || next == ""
+		if app.Format == "ndjson" {
+			if err := app.writeNDJSONPage(listEnvelope(items, list.Limit, next, list.ItemType, list.Fields), last); err != nil {
+				return err
+			}
+		} else {
+			results = append(results, items...)
+		}
+		switch {
+		case !last && next == cursor:
+			return fmt.Errorf("pagination did not advance past cursor %q", cursor)
+		case !last:
+			cursor = next
+			continue
+		case app.Format == "ndjson":
+			return nil
+		case app.IsPlain():
+			list.Plain(app.Stdout, results, next)
+			return nil
+		}
+		return app.renderEnvelope(listEnvelope(results, list.Limit, next, list.ItemType, list.Fields))
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lockedBuffer lets the upstream handler read what the command has written.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestListAllStreamsNDJSONPageByPage(t *testing.T) {
+	var stdout lockedBuffer
+	var writtenBeforePage2 string
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "c2" {
+			writtenBeforePage2 = stdout.String()
+			_, _ = w.Write([]byte(`{"results":[{"id":"2","key":"OPS","name":"Operations","type":"global","status":"current"}],"_links":{}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","key":"ENG","name":"Engineering","type":"global","status":"current"}],"_links":{"next":"/wiki/api/v2/spaces?cursor=c2"}}`))
+	})
+
+	if detail, code := app.runInProcess([]string{"spaces", "list", "--all", "--limit", "1", "--format=ndjson"}, &stdout); code != ExitOK {
+		t.Fatalf("spaces list --all = %d %+v", code, detail)
+	}
+	if writtenBeforePage2 != `{"id":"1","key":"ENG","name":"Engineering","type":"global","status":"current"}`+"\n" {
+		t.Errorf("written before page 2 was fetched = %q, want the first row only", writtenBeforePage2)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], `"key":"OPS"`) {
+		t.Fatalf("ndjson lines = %q", lines)
+	}
+	var trailer struct {
+		Page PageWindow `json:"page"`
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &trailer); err != nil || trailer.Page.NextCursor != "" || trailer.Page.Limit != 1 {
+		t.Errorf("trailer = %s (%v)", lines[2], err)
+	}
+
+	var out bytes.Buffer
+	if detail, code := app.runInProcess([]string{"spaces", "list", "--all", "--limit", "1", "--fields", "key"}, &out); code != ExitOK {
+		t.Fatalf("spaces list --all json = %d %+v", code, detail)
+	}
+	if got := strings.Join(strings.Fields(out.String()), ""); !strings.Contains(got, `"results":[{"key":"ENG"},{"key":"OPS"}]`) || strings.Contains(got, "nextCursor") {
+		t.Errorf("json --all = %s", out.String())
+	}
+}
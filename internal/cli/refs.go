@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+// referenceFlags are the ID flags that also accept URLs and references.
+var referenceFlags = map[string]bool{"page-id": true, "space-id": true, "ancestor": true}
+
+// pageRef is a parsed --page-id value: an ID, or a space key and title to
+// look up.
+type pageRef struct {
+	ID       string
+	SpaceKey string
+	Title    string
+}
+
+// parsePageRef understands page URLs (cloud and Data Center), tiny links, and
+// SPACEKEY:Title. Anything else is passed through as an ID.
+func parsePageRef(input string) (pageRef, error) {
+	if !isURLReference(input) {
+		if key, title, ok := strings.Cut(input, ":"); ok {
+			if key == "" || title == "" {
+				return pageRef{}, fmt.Errorf("expected SPACEKEY:Title, got %q", input)
+			}
+			return pageRef{SpaceKey: key, Title: title}, nil
+		}
+		return pageRef{ID: input}, nil
+	}
+
+	u, err := url.Parse(input)
+	if err != nil {
+		return pageRef{}, fmt.Errorf("invalid page URL %q: %v", input, err)
+	}
+	if id := u.Query().Get("pageId"); id != "" {
+		return pageRef{ID: id}, nil
+	}
+	segments := pathSegments(u)
+	for i, segment := range segments {
+		switch {
+		case segment == "pages":
+			for _, next := range segments[i+1:] {
+				if isNumeric(next) {
+					return pageRef{ID: next}, nil
+				}
+			}
+		case segment == "x" && i+1 < len(segments):
+			id, err := decodeTinyLink(segments[i+1])
+			if err != nil {
+				return pageRef{}, err
+			}
+			return pageRef{ID: id}, nil
+		case segment == "display" && i+2 < len(segments):
+			return pageRef{SpaceKey: segments[i+1], Title: strings.ReplaceAll(segments[i+2], "+", " ")}, nil
+		}
+	}
+	return pageRef{}, fmt.Errorf("unrecognized page URL %q; expected .../pages/<id>/..., /x/<tiny>, /display/<KEY>/<Title>, or ?pageId=<id>", input)
+}
+
+// parseSpaceRef returns the space ID, or the space key from a space URL.
+func parseSpaceRef(input string) (id, key string, err error) {
+	if !isURLReference(input) {
+		return input, "", nil
+	}
+	u, err := url.Parse(input)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid space URL %q: %v", input, err)
+	}
+	if key := u.Query().Get("key"); key != "" {
+		return "", key, nil
+	}
+	if key := u.Query().Get("spaceKey"); key != "" {
+		return "", key, nil
+	}
+	segments := pathSegments(u)
+	for i, segment := range segments {
+		if (segment == "spaces" || segment == "display") && i+1 < len(segments) {
+			return "", segments[i+1], nil
+		}
+	}
+	return "", "", fmt.Errorf("unrecognized space URL %q; expected .../spaces/<KEY>/... or /display/<KEY>", input)
+}
+
+func isURLReference(input string) bool {
+	return strings.HasPrefix(input, "/") || strings.Contains(input, "://")
+}
+
+func pathSegments(u *url.URL) []string {
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// decodeTinyLink reverses Confluence's tiny-link scheme: the page ID as 8
+// little-endian bytes, base64-encoded with trailing 'A's and padding
+// dropped, '/' written as '-' and '+' as '_'.
+func decodeTinyLink(code string) (string, error) {
+	encoded := strings.NewReplacer("-", "/", "_", "+").Replace(code)
+	if encoded == "" || len(encoded) > 11 {
+		return "", fmt.Errorf("invalid tiny link code %q", code)
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded + strings.Repeat("A", 11-len(encoded)) + "=")
+	if err != nil {
+		return "", fmt.Errorf("invalid tiny link code %q: %v", code, err)
+	}
+	id := binary.LittleEndian.Uint64(raw)
+	if id == 0 {
+		return "", fmt.Errorf("invalid tiny link code %q", code)
+	}
+	return strconv.FormatUint(id, 10), nil
+}
+
+// resolveReferences rewrites --page-id, --ancestor, and --space-id values
+// that are URLs, tiny links, or SPACEKEY:Title into IDs before the command
+// runs, and records each rewrite for the output envelope.
+func (app *App) resolveReferences(ctx *kong.Context) error {
+	for _, flag := range ctx.Selected().Flags {
+		if !referenceFlags[flag.Name] {
+			continue
+		}
+		target := flag.Target
+		switch target.Kind() {
+		case reflect.String:
+			id, err := app.resolveReference(flag.Name, target.String())
+			if err != nil {
+				return err
+			}
+			target.SetString(id)
+		case reflect.Slice:
+			for i := 0; i < target.Len(); i++ {
+				id, err := app.resolveReference(flag.Name, target.Index(i).String())
+				if err != nil {
+					return err
+				}
+				target.Index(i).SetString(id)
+			}
+		}
+	}
+	return nil
+}
+
+func (app *App) resolveReference(flag, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return input, nil
+	}
+	hint := helpHint(commandName(app.kongCtx.Command()))
+
+	var id string
+	switch flag {
+	case "page-id", "ancestor":
+		ref, err := parsePageRef(input)
+		if err != nil {
+			return "", validationErrorf(hint, "--%s: %v", flag, err)
+		}
+		id = ref.ID
+		if id == "" {
+			if id, err = app.Client.FindPageID(ref.SpaceKey, ref.Title); err != nil {
+				return "", err
+			}
+			if id == "" {
+				return "", validationErrorf(hint, "--%s: no page titled %q in space %s", flag, ref.Title, ref.SpaceKey)
+			}
+		}
+	case "space-id":
+		spaceID, key, err := parseSpaceRef(input)
+		if err != nil {
+			return "", validationErrorf(hint, "--space-id: %v", err)
+		}
+		id = spaceID
+		if id == "" {
+			if id, err = app.Client.FindSpaceID(key); err != nil {
+				return "", err
+			}
+			if id == "" {
+				return "", validationErrorf(hint, "--space-id: no space with key %s", key)
+			}
+		}
+	}
+
+	if id != input {
+		app.resolved = append(app.resolved, ResolvedRef{Flag: flag, Input: input, ID: id})
+	}
+	return id, nil
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParsePageRef(t *testing.T) {
+	tests := []struct {
+		input string
+		want  pageRef
+	}{
+		{input: "12345", want: pageRef{ID: "12345"}},
+		{input: "root", want: pageRef{ID: "root"}},
+		{input: "https://example.atlassian.net/wiki/spaces/ENG/pages/12345/Release+Runbook", want: pageRef{ID: "12345"}},
+		{input: "https://example.atlassian.net/wiki/spaces/ENG/pages/edit-v2/12345", want: pageRef{ID: "12345"}},
+		{input: "https://confluence.example.com/pages/viewpage.action?pageId=777", want: pageRef{ID: "777"}},
+		{input: "https://confluence.example.com/display/ENG/Release+Runbook", want: pageRef{SpaceKey: "ENG", Title: "Release Runbook"}},
+		{input: "https://example.atlassian.net/wiki/x/QQAB", want: pageRef{ID: "65601"}},
+		{input: "/x/QQAB", want: pageRef{ID: "65601"}},
+		{input: "ENG:Release Runbook", want: pageRef{SpaceKey: "ENG", Title: "Release Runbook"}},
+		{input: "ENG:CI/CD: Pipelines", want: pageRef{SpaceKey: "ENG", Title: "CI/CD: Pipelines"}},
+	}
+	for _, tc := range tests {
+		got, err := parsePageRef(tc.input)
+		if err != nil {
+			t.Errorf("parsePageRef(%q): %v", tc.input, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parsePageRef(%q) = %+v, want %+v", tc.input, got, tc.want)
+		}
+	}
+
+	for _, input := range []string{":Title", "ENG:", "https://example.atlassian.net/wiki/home", "https://example.atlassian.net/wiki/x/!!"} {
+		if _, err := parsePageRef(input); err == nil {
+			t.Errorf("parsePageRef(%q) succeeded, want error", input)
+		}
+	}
+}
+
+func TestParseSpaceRef(t *testing.T) {
+	tests := []struct {
+		input, wantID, wantKey string
+	}{
+		{input: "98765", wantID: "98765"},
+		{input: "https://example.atlassian.net/wiki/spaces/ENG/overview", wantKey: "ENG"},
+		{input: "https://confluence.example.com/display/OPS", wantKey: "OPS"},
+		{input: "https://confluence.example.com/spaces/viewspace.action?key=DOC", wantKey: "DOC"},
+	}
+	for _, tc := range tests {
+		id, key, err := parseSpaceRef(tc.input)
+		if err != nil || id != tc.wantID || key != tc.wantKey {
+			t.Errorf("parseSpaceRef(%q) = %q, %q, %v; want %q, %q", tc.input, id, key, err, tc.wantID, tc.wantKey)
+		}
+	}
+	if _, _, err := parseSpaceRef("https://example.atlassian.net/wiki/home"); err == nil {
+		t.Error("parseSpaceRef accepted a URL without a space")
+	}
+}
+
+func TestDecodeTinyLinkRoundTrip(t *testing.T) {
+	for _, id := range []uint64{1, 65601, 8975222682, 1 << 40} {
+		raw := make([]byte, 8)
+		binary.LittleEndian.PutUint64(raw, id)
+		code := base64.StdEncoding.EncodeToString(raw)
+		code = strings.TrimRight(strings.TrimRight(code, "="), "A")
+		code = strings.NewReplacer("/", "-", "+", "_").Replace(code)
+
+		got, err := decodeTinyLink(code)
+		if err != nil || got != strconv.FormatUint(id, 10) {
+			t.Errorf("decodeTinyLink(%q) = %q, %v; want %d", code, got, err, id)
+		}
+	}
+}
+
+func TestResolveReferencesEchoesResolvedID(t *testing.T) {
+	var gotCQL string
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/rest/api/search"):
+			gotCQL = r.URL.Query().Get("cql")
+			if strings.Contains(gotCQL, "Missing") {
+				_, _ = w.Write([]byte(`{"results":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"results":[{"content":{"id":"4242","type":"page","title":"Release Runbook"}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/pages/4242"), strings.HasSuffix(r.URL.Path, "/pages/65601"):
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			_, _ = w.Write([]byte(`{"id":"` + id + `","title":"Release Runbook","spaceId":"S1","status":"current","version":{"number":2}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"unexpected ` + r.URL.Path + `"}]}`))
+		}
+	})
+
+	var stdout bytes.Buffer
+	if detail, code := app.runInProcess([]string{"pages", "get", "--page-id", "ENG:Release Runbook", "--format=json"}, &stdout); code != ExitOK {
+		t.Fatalf("runInProcess = %d %+v", code, detail)
+	}
+	if gotCQL != `type=page AND space="ENG" AND title="Release Runbook"` {
+		t.Errorf("lookup CQL = %q", gotCQL)
+	}
+	for _, want := range []string{`"id": "4242"`, `"flag": "page-id"`, `"input": "ENG:Release Runbook"`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %s:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if detail, code := app.runInProcess([]string{"pages", "get", "--page-id", "https://example.atlassian.net/wiki/x/QQAB", "--format=json"}, &stdout); code != ExitOK {
+		t.Fatalf("tiny link runInProcess = %d %+v", code, detail)
+	}
+	if !strings.Contains(stdout.String(), `"id": "65601"`) {
+		t.Errorf("tiny link output:\n%s", stdout.String())
+	}
+
+	detail, code := app.runInProcess([]string{"pages", "get", "--page-id", "ENG:Missing", "--format=json"}, &stdout)
+	if code != ExitValidation || !strings.Contains(detail.Message, `no page titled "Missing" in space ENG`) {
+		t.Errorf("unresolved reference = %d %+v", code, detail)
+	}
+}
@@ -16,6 +16,64 @@ func renderJSON(w io.Writer, v any) error {
 	return encoder.Encode(v)
 }
 
+// renderNDJSON writes one compact line per list result followed by a trailer
+// line with the remaining envelope keys (page and schema). Single-item
+// envelopes are written as one compact line.
+func renderNDJSON(w io.Writer, v any) error {
+	return writeNDJSON(w, v, true)
+}
+
+// writeNDJSON writes the result lines of a list envelope, and the trailer line
+// when trailer is set.
+func writeNDJSON(w io.Writer, v any, trailer bool) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var envelope fieldObject
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return err
+	}
+	results, ok, err := listResults(envelope)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return json.NewEncoder(w).Encode(envelope)
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+	if !trailer {
+		return nil
+	}
+	rest := fieldObject{}
+	for _, entry := range envelope {
+		if entry.Key != "results" {
+			rest = append(rest, entry)
+		}
+	}
+	return encoder.Encode(rest)
+}
+
+// listResults returns the results array of a decoded envelope; ok is false
+// for single-item envelopes.
+func listResults(envelope fieldObject) (results []any, ok bool, err error) {
+	raw, ok := envelope.get("results")
+	if !ok || raw == nil {
+		return nil, ok, nil
+	}
+	results, isList := raw.([]any)
+	if !isList {
+		return nil, true, fmt.Errorf("envelope results are %T, not a list", raw)
+	}
+	return results, true, nil
+}
+
 func renderSpacesPlain(w io.Writer, results []SpaceSummary, nextCursor string) {
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 	discardWrite(fmt.Fprintln(tw, "ID\tKEY\tNAME\tTYPE\tSTATUS"))
@@ -28,6 +86,23 @@ func renderSpacesPlain(w io.Writer, results []SpaceSummary, nextCursor string) {
 	}
 }
 
+func renderSpacePlain(w io.Writer, space SpaceDetail) {
+	discardWrite(fmt.Fprintf(w, "ID: %s\n", space.ID))
+	discardWrite(fmt.Fprintf(w, "Key: %s\n", space.Key))
+	discardWrite(fmt.Fprintf(w, "Name: %s\n", space.Name))
+	discardWrite(fmt.Fprintf(w, "Type: %s\n", space.Type))
+	discardWrite(fmt.Fprintf(w, "Status: %s\n", space.Status))
+	if space.HomepageID != "" {
+		discardWrite(fmt.Fprintf(w, "Homepage ID: %s\n", space.HomepageID))
+	}
+	if len(space.Labels) > 0 {
+		discardWrite(fmt.Fprintf(w, "Labels: %s\n", strings.Join(space.Labels, ", ")))
+	}
+	if space.Description != "" {
+		discardWrite(fmt.Fprintf(w, "\nDescription:\n%s\n", space.Description))
+	}
+}
+
 func renderPagesPlain(w io.Writer, results []PageSummary, nextCursor string) {
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 	discardWrite(fmt.Fprintln(tw, "ID\tTITLE\tSTATUS\tSPACE ID\tVERSION"))
@@ -48,6 +123,9 @@ func renderPagePlain(w io.Writer, page PageDetail) {
 	if page.ParentID != "" {
 		discardWrite(fmt.Fprintf(w, "Parent ID: %s\n", page.ParentID))
 	}
+	if len(page.Breadcrumb) > 0 {
+		discardWrite(fmt.Fprintf(w, "Breadcrumb: %s\n", breadcrumbTrail(page.Breadcrumb)))
+	}
 	if page.Version != nil {
 		discardWrite(fmt.Fprintf(w, "Version: %d\n", page.Version.Number))
 	}
@@ -69,18 +147,6 @@ func renderPagePlain(w io.Writer, page PageDetail) {
 	}
 }
 
-func renderSearchPlain(w io.Writer, results []SearchSummary, nextCursor string) {
-	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	discardWrite(fmt.Fprintln(tw, "ID\tTITLE\tSPACE ID"))
-	for _, result := range results {
-		discardWrite(fmt.Fprintf(tw, "%s\t%s\t%s\n", result.ID, result.Title, result.SpaceID))
-	}
-	_ = tw.Flush()
-	if nextCursor != "" {
-		discardWrite(fmt.Fprintf(w, "\nNext cursor: %s\n", nextCursor))
-	}
-}
-
 func renderTreePlain(w io.Writer, tree PageTree) {
 	discardWrite(fmt.Fprintf(w, "Root page: %s\n", tree.RootPageID))
 	discardWrite(fmt.Fprintf(w, "Depth: %d\n", tree.Depth))
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// renderDelimited writes list results as CSV or TSV with a header row built
+// from schema.fields. Nested objects become dot-path columns; arrays are kept
+// as compact JSON in a single cell.
+func renderDelimited(w io.Writer, v any, comma rune, hint string) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var envelope fieldObject
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return err
+	}
+	results, ok, err := listResults(envelope)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return validationError("csv and tsv output are only available for list commands; use --format json", hint)
+	}
+	rows := make([]fieldObject, 0, len(results))
+	for _, result := range results {
+		row, _ := result.(fieldObject)
+		rows = append(rows, row)
+	}
+
+	var schema Schema
+	if raw, ok := envelope.get("schema"); ok {
+		b, _ := json.Marshal(raw)
+		_ = json.Unmarshal(b, &schema)
+	}
+	columns := delimitedColumns(schema.Fields, rows)
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = cellValue(lookupPath(row, strings.Split(column, ".")))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// delimitedColumns expands schema fields that hold objects into their nested
+// dot-paths, in first-seen order across all rows.
+func delimitedColumns(fields []string, rows []fieldObject) []string {
+	var columns []string
+	for _, field := range fields {
+		columns = append(columns, expandColumn(field, rows)...)
+	}
+	return columns
+}
+
+func expandColumn(path string, rows []fieldObject) []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, row := range rows {
+		object, ok := lookupPath(row, strings.Split(path, ".")).(fieldObject)
+		if !ok {
+			continue
+		}
+		for _, entry := range object {
+			if !seen[entry.Key] {
+				seen[entry.Key] = true
+				keys = append(keys, entry.Key)
+			}
+		}
+	}
+	if keys == nil {
+		return []string{path}
+	}
+	var columns []string
+	for _, key := range keys {
+		columns = append(columns, expandColumn(path+"."+key, rows)...)
+	}
+	return columns
+}
+
+func lookupPath(value any, path []string) any {
+	for _, part := range path {
+		object, ok := value.(fieldObject)
+		if !ok {
+			return nil
+		}
+		if value, ok = object.get(part); !ok {
+			return nil
+		}
+	}
+	return value
+}
+
+func cellValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRenderDelimitedCSV(t *testing.T) {
+	envelope := listEnvelope([]PageSummary{
+		{ID: "1", Title: "Runbook, \"on-call\"", SpaceID: "S", Status: "current", VersionNumber: 3},
+		{ID: "2", Title: "Notes", SpaceID: "S", Status: "current"},
+	}, 10, "", "page-summary", []string{"id", "title", "versionNumber"})
+
+	var out bytes.Buffer
+	if err := renderDelimited(&out, envelope, ',', ""); err != nil {
+		t.Fatalf("renderDelimited: %v", err)
+	}
+	want := "id,title,versionNumber\n1,\"Runbook, \"\"on-call\"\"\",3\n2,Notes,\n"
+	if out.String() != want {
+		t.Fatalf("csv =\n%q\nwant\n%q", out.String(), want)
+	}
+}
+
+func TestRenderDelimitedFlattensNestedFields(t *testing.T) {
+	envelope := listEnvelope([]PageDetail{
+		{ID: "1", Title: "A\tB", Version: &PageVersionInfo{Number: 2, AuthorID: "u1"}},
+		{ID: "2", Title: "C"},
+	}, 10, "", "page-detail", []string{"id", "title", "version"})
+
+	projected, err := projectEnvelope(envelope, []string{"id", "title", "version"}, "")
+	if err != nil {
+		t.Fatalf("projectEnvelope: %v", err)
+	}
+	var out bytes.Buffer
+	if err := renderDelimited(&out, projected, '\t', ""); err != nil {
+		t.Fatalf("renderDelimited: %v", err)
+	}
+	want := "id\ttitle\tversion.number\tversion.createdAt\tversion.authorId\n" +
+		"1\t\"A\tB\"\t2\t0001-01-01T00:00:00Z\tu1\n" +
+		"2\tC\t\t\t\n"
+	if out.String() != want {
+		t.Fatalf("tsv =\n%q\nwant\n%q", out.String(), want)
+	}
+}
+
+func TestRenderDelimitedRejectsItems(t *testing.T) {
+	err := renderDelimited(&bytes.Buffer{}, itemEnvelope(VersionInfo{Version: "1"}, "version", []string{"version"}), ',', "")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want ValidationError", err)
+	}
+}
+
+func TestRenderersRejectNonListResults(t *testing.T) {
+	payload := map[string]any{"results": "not a list"}
+	var out bytes.Buffer
+	if err := renderDelimited(&out, payload, ',', ""); err == nil {
+		t.Error("renderDelimited accepted non-list results")
+	}
+	if err := renderNDJSON(&out, payload); err == nil {
+		t.Error("renderNDJSON accepted non-list results")
+	}
+
+	out.Reset()
+	if err := renderNDJSON(&out, map[string]any{"results": nil}); err != nil || out.String() != "{}\n" {
+		t.Errorf("null results = %q, %v", out.String(), err)
+	}
+}
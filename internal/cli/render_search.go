@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+func renderSearchPlain(w io.Writer, results []SearchSummary, nextCursor string) {
+	for i, result := range results {
+		if i > 0 {
+			discardWrite(fmt.Fprintln(w))
+		}
+		space := result.SpaceID
+		if result.SpaceKey != "" {
+			space = result.SpaceKey
+		}
+		discardWrite(fmt.Fprintf(w, "%s (id:%s, space:%s)\n", result.Title, result.ID, space))
+		if len(result.Breadcrumbs) > 0 {
+			titles := make([]string, len(result.Breadcrumbs))
+			for i, crumb := range result.Breadcrumbs {
+				titles[i] = crumb.Title
+			}
+			discardWrite(fmt.Fprintf(w, "  in: %s\n", strings.Join(titles, " > ")))
+		}
+		if result.LastModified != nil {
+			discardWrite(fmt.Fprintf(w, "  modified: %s\n", result.LastModified.Format(time.DateOnly)))
+		}
+		renderExcerptPlain(w, result.Excerpt, result.Highlights)
+	}
+	if nextCursor != "" {
+		discardWrite(fmt.Fprintf(w, "\nNext cursor: %s\n", nextCursor))
+	}
+}
+
+func renderContentSearchPlain(w io.Writer, results []ContentSummary, nextCursor string) {
+	for i, result := range results {
+		if i > 0 {
+			discardWrite(fmt.Fprintln(w))
+		}
+		where := "id:" + result.ID
+		if result.SpaceKey != "" {
+			where += ", space:" + result.SpaceKey
+		}
+		if result.Container != nil {
+			where += fmt.Sprintf(", on %s %q id:%s", result.Container.Type, result.Container.Title, result.Container.ID)
+		}
+		discardWrite(fmt.Fprintf(w, "[%s] %s (%s)\n", result.Type, result.Title, where))
+		renderExcerptPlain(w, result.Excerpt, result.Highlights)
+	}
+	if nextCursor != "" {
+		discardWrite(fmt.Fprintf(w, "\nNext cursor: %s\n", nextCursor))
+	}
+}
+
+func renderExcerptPlain(w io.Writer, excerpt string, highlights []HighlightSpan) {
+	if excerpt != "" {
+		discardWrite(fmt.Fprintf(w, "  %s\n", emphasize(excerpt, highlights)))
+	}
+}
+
+// emphasize wraps highlighted spans in Markdown bold, matching the Markdown
+// that plain mode renders for page bodies.
+func emphasize(text string, highlights []HighlightSpan) string {
+	runes := []rune(text)
+	var out strings.Builder
+	next := 0
+	for _, span := range highlights {
+		if span.Start < next || span.End > len(runes) || span.Start >= span.End {
+			continue
+		}
+		out.WriteString(string(runes[next:span.Start]))
+		out.WriteString("**" + string(runes[span.Start:span.End]) + "**")
+		next = span.End
+	}
+	out.WriteString(string(runes[next:]))
+	return out.String()
+}
+
+func newHighlightSpans(highlights []confluence.Highlight) []HighlightSpan {
+	if len(highlights) == 0 {
+		return nil
+	}
+	spans := make([]HighlightSpan, len(highlights))
+	for i, highlight := range highlights {
+		spans[i] = HighlightSpan{Start: highlight.Start, End: highlight.End}
+	}
+	return spans
+}
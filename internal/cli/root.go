@@ -4,32 +4,57 @@ import "time"
 
 // CLI defines the agent-first command surface for the confluence binary.
 type CLI struct {
-	URL     string        `name:"url" help:"Confluence base URL" env:"CONFLUENCE_URL"`
-	Email   string        `name:"email" help:"Atlassian account email" env:"CONFLUENCE_EMAIL"`
-	Token   string        `name:"token" help:"Atlassian API token" env:"CONFLUENCE_API_TOKEN"`
-	Format  string        `name:"format" help:"Output format: json or plain" enum:"json,plain" default:"json"`
-	Timeout time.Duration `name:"timeout" help:"HTTP timeout" default:"30s"`
+	URL          string        `name:"url" help:"Confluence base URL" env:"CONFLUENCE_URL"`
+	Email        string        `name:"email" help:"Atlassian account email" env:"CONFLUENCE_EMAIL"`
+	Token        string        `name:"token" help:"Atlassian API token" env:"CONFLUENCE_API_TOKEN"`
+	Flavor       string        `name:"flavor" help:"Deployment flavor: cloud or server (Data Center)" enum:",cloud,server" default:"" env:"CONFLUENCE_FLAVOR"`
+	Format       string        `name:"format" help:"Output format: json, plain, ndjson, csv, tsv, or template" enum:"json,plain,ndjson,csv,tsv,template" default:"json"`
+	Timeout      time.Duration `name:"timeout" help:"HTTP timeout" default:"30s"`
+	Fields       []string      `name:"fields" help:"Comma-separated JSON fields to keep, e.g. id,title,version.number" placeholder:"FIELD,..."`
+	Filter       string        `name:"filter" help:"Keep list results matching a JMESPath expression, e.g. contains(title, 'Runbook')"`
+	Template     string        `name:"template" help:"Go template for --format template, executed against the JSON envelope"`
+	TemplateFile string        `name:"template-file" help:"Read the --format template Go template from a file" type:"path"`
+	Profile      string        `name:"profile" help:"Config profile for flag defaults" env:"CONFLUENCE_PROFILE" default:"default"`
 
 	Spaces  SpacesCmd  `cmd:"" help:"Space discovery commands"`
 	Pages   PagesCmd   `cmd:"" help:"Page discovery commands"`
+	Search  SearchCmd  `cmd:"" help:"Search pages, blog posts, comments, attachments, and spaces"`
+	Cql     CqlCmd     `cmd:"" name:"cql" help:"Offline CQL tools"`
 	Auth    AuthCmd    `cmd:"" help:"Credential management commands"`
+	Config  ConfigCmd  `cmd:"" help:"Per-profile flag defaults"`
+	Batch   BatchCmd   `cmd:"" help:"Run read commands from JSONL stdin with bounded concurrency"`
+	Mcp     McpCmd     `cmd:"" name:"mcp" help:"Model Context Protocol server"`
+	Serve   ServeCmd   `cmd:"" help:"Serve read commands as a local HTTP API"`
+	Schema  SchemaCmd  `cmd:"" help:"Print JSON Schema for command output envelopes"`
+	Help    HelpCmd    `cmd:"" name:"help" help:"Describe every command, flag, and output contract"`
 	Version VersionCmd `cmd:"" name:"version" help:"Print CLI version"`
 }
 
 // SpacesCmd groups space commands.
 type SpacesCmd struct {
 	List SpacesListCmd `cmd:"" help:"List spaces with compact summaries"`
+	Get  SpacesGetCmd  `cmd:"" help:"Get a space by ID or key"`
 }
 
 // PagesCmd groups page commands.
 type PagesCmd struct {
-	List   PagesListCmd   `cmd:"" help:"List pages in a space"`
-	Get    PagesGetCmd    `cmd:"" help:"Get a page by ID"`
-	Tree   PagesTreeCmd   `cmd:"" help:"Traverse a bounded page tree"`
-	Search PagesSearchCmd `cmd:"" help:"Search pages with safe query inputs"`
+	List        PagesListCmd        `cmd:"" help:"List pages in a space"`
+	Get         PagesGetCmd         `cmd:"" help:"Get a page by ID"`
+	GetMany     PagesGetManyCmd     `cmd:"" name:"get-many" help:"Get many pages by ID in bulk"`
+	Tree        PagesTreeCmd        `cmd:"" help:"Traverse a bounded page tree"`
+	Ancestors   PagesAncestorsCmd   `cmd:"" help:"List the ancestors of a page, root first"`
+	Descendants PagesDescendantsCmd `cmd:"" help:"List everything below a page with depth and parent"`
+	Search      PagesSearchCmd      `cmd:"" help:"Search pages with safe query inputs"`
 }
 
 // AuthCmd groups credential commands.
 type AuthCmd struct {
 	Login AuthLoginCmd `cmd:"" help:"Store credentials for later non-interactive use"`
 }
+
+// ConfigCmd groups config file commands.
+type ConfigCmd struct {
+	Get  ConfigGetCmd  `cmd:"" help:"Show the effective value of a config key and its source"`
+	Set  ConfigSetCmd  `cmd:"" help:"Store a flag default in the active profile"`
+	List ConfigListCmd `cmd:"" help:"List every config key with its effective value and source"`
+}
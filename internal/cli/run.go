@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"text/template"
 
 	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
 	"github.com/alecthomas/kong"
+	"github.com/jmespath/go-jmespath"
 )
 
 const (
@@ -28,16 +30,109 @@ type App struct {
 	URL     string
 	Email   string
 	Token   string
+	Flavor  string
+	Fields  []string
+
+	config   *ConfigFile
+	kongCtx  *kong.Context
+	template *template.Template
+	filter   *jmespath.JMESPath
+	resolved []ResolvedRef
+	// inProcess is set for commands run by serve, batch, and MCP, whose
+	// stdin belongs to the caller and must not be read as command input.
+	inProcess bool
 }
 
 func (app *App) IsPlain() bool {
 	return app.Format == "plain"
 }
 
+// renderEnvelope writes a success envelope in the requested machine format,
+// filtered by --filter and projected to --fields when they were requested.
+func (app *App) renderEnvelope(envelope projectable) error {
+	payload, err := app.shapeEnvelope(envelope)
+	if err != nil {
+		return err
+	}
+	switch app.Format {
+	case "ndjson":
+		return renderNDJSON(app.Stdout, payload)
+	case "csv":
+		return renderDelimited(app.Stdout, payload, ',', helpHint(commandName(app.kongCtx.Command())))
+	case "tsv":
+		return renderDelimited(app.Stdout, payload, '\t', helpHint(commandName(app.kongCtx.Command())))
+	case "template":
+		return renderTemplate(app.Stdout, app.template, payload, helpHint(commandName(app.kongCtx.Command())))
+	default:
+		return renderJSON(app.Stdout, payload)
+	}
+}
+
+// writeNDJSONPage writes one page of a list as ndjson rows; last adds the
+// trailer line, so earlier pages can be written before later ones arrive.
+func (app *App) writeNDJSONPage(envelope projectable, last bool) error {
+	payload, err := app.shapeEnvelope(envelope)
+	if err != nil {
+		return err
+	}
+	return writeNDJSON(app.Stdout, payload, last)
+}
+
+// shapeEnvelope applies resolved references, --filter, and --fields.
+func (app *App) shapeEnvelope(envelope projectable) (any, error) {
+	if len(app.resolved) > 0 {
+		envelope = envelope.withResolved(app.resolved)
+	}
+	if app.filter != nil {
+		filtered, err := filterEnvelope(envelope, app.filter, helpHint(commandName(app.kongCtx.Command())))
+		if err != nil {
+			return nil, err
+		}
+		envelope = filtered
+	}
+	if len(app.Fields) > 0 {
+		return projectEnvelope(envelope, app.Fields, helpHint(commandName(app.kongCtx.Command())))
+	}
+	return envelope, nil
+}
+
+// configureOutput checks output flag combinations and parses any template
+// before the command runs.
+func (app *App) configureOutput(cli *CLI) error {
+	if len(app.Fields) > 0 && (app.IsPlain() || app.Format == "template") {
+		return fmt.Errorf("--fields is not supported with --format %s", app.Format)
+	}
+	if cli.Filter != "" {
+		if app.IsPlain() {
+			return fmt.Errorf("--filter is not supported with --format plain")
+		}
+		expr, err := parseFilter(cli.Filter)
+		if err != nil {
+			return fmt.Errorf("invalid --filter: %w", err)
+		}
+		app.filter = expr
+	}
+	if app.Format != "template" {
+		if cli.Template != "" || cli.TemplateFile != "" {
+			return fmt.Errorf("--template and --template-file require --format template")
+		}
+		return nil
+	}
+	tmpl, err := loadTemplate(cli.Template, cli.TemplateFile)
+	if err != nil {
+		return err
+	}
+	app.template = tmpl
+	return nil
+}
+
 type ErrorDetail struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Hint    string `json:"hint,omitempty"`
+
+	// upstreamStatus is the Confluence HTTP status behind an API error.
+	upstreamStatus int
 }
 
 type ErrorEnvelope struct {
@@ -56,17 +151,34 @@ func writeError(w io.Writer, code, message, hint string) {
 	discardWrite(fmt.Fprintln(w, string(b)))
 }
 
+// WarningEnvelope reports a problem that did not stop the command.
+type WarningEnvelope struct {
+	Warning ErrorDetail `json:"warning"`
+}
+
+func writeWarning(w io.Writer, code, message, hint string) {
+	b, _ := json.Marshal(WarningEnvelope{Warning: ErrorDetail{Code: code, Message: message, Hint: hint}})
+	discardWrite(fmt.Fprintln(w, string(b)))
+}
+
 func Run(args []string, stdout, stderr io.Writer, version string) int {
 	if maybeWriteHelp(args, stdout) {
 		return ExitOK
 	}
 	args = stripHelpFlags(args)
 
+	cfg, err := loadConfig(profileFromArgs(args))
+	if err != nil {
+		writeError(stderr, "CONFIG", err.Error(), "Fix or remove the config file, or pick another --profile.")
+		return ExitValidation
+	}
+
 	var cli CLI
 	parser, err := kong.New(&cli,
 		kong.Name("confluence"),
 		kong.Description("Agent-first Confluence Cloud CLI"),
 		kong.Writers(stdout, stderr),
+		kong.Resolvers(configResolver(cfg)),
 	)
 	if err != nil {
 		writeError(stderr, "INTERNAL", err.Error(), "Rebuild the binary or inspect the CLI wiring.")
@@ -87,54 +199,83 @@ func Run(args []string, stdout, stderr io.Writer, version string) int {
 		URL:     strings.TrimSpace(cli.URL),
 		Email:   strings.TrimSpace(cli.Email),
 		Token:   strings.TrimSpace(cli.Token),
+		Flavor:  cli.Flavor,
+		Fields:  parseFields(cli.Fields),
+		config:  cfg,
+		kongCtx: ctx,
+	}
+
+	if err := app.configureOutput(&cli); err != nil {
+		writeError(stderr, "VALIDATION", err.Error(), helpHint(commandName(ctx.Command())))
+		return ExitValidation
 	}
 
 	if commandNeedsClient(ctx.Command()) {
-		creds, err := resolveCredentials(Credentials{URL: app.URL, Email: app.Email, Token: app.Token})
+		creds, err := resolveCredentials(Credentials{URL: app.URL, Email: app.Email, Token: app.Token, Flavor: app.Flavor})
 		if err != nil {
 			writeError(stderr, "AUTH_STORE", err.Error(), "Use explicit flags/env vars or rerun `confluence auth login`.")
 			return ExitError
 		}
-		if creds.URL == "" || creds.Email == "" || creds.Token == "" {
-			writeError(stderr, "VALIDATION", "missing credentials: provide --url, --email, and --token, or store them with `confluence auth login`", helpHint("auth login"))
+		if !creds.complete() {
+			writeError(stderr, "VALIDATION", missingCredentialsMessage(creds), helpHint("auth login"))
 			return ExitValidation
 		}
 		app.URL = creds.URL
 		app.Email = creds.Email
 		app.Token = creds.Token
-		app.Client = confluence.NewClient(confluence.Options{
-			BaseURL: creds.URL,
-			Email:   creds.Email,
-			Token:   creds.Token,
-			Timeout: cli.Timeout,
-		})
+		app.Flavor = creds.flavor()
+		app.Client = newClient(creds, cli.Timeout, stderr)
+		if err := app.resolveReferences(ctx); err != nil {
+			detail, code := classifyError(err)
+			writeError(stderr, detail.Code, detail.Message, detail.Hint)
+			return code
+		}
 	}
 
 	if err := ctx.Run(app); err != nil {
-		var validationErr *ValidationError
-		var apiErr *confluence.APIError
-		switch {
-		case errors.As(err, &validationErr):
-			writeError(stderr, "VALIDATION", validationErr.Message, validationErr.Hint)
-			return ExitValidation
-		case errors.As(err, &apiErr):
-			if apiErr.StatusCode == 401 || apiErr.StatusCode == 403 {
-				writeError(stderr, "AUTH_FAILED", apiErr.Error(), "Verify your Confluence credentials or rerun `confluence auth login`.")
-				return ExitAuth
-			}
-			writeError(stderr, "API_ERROR", apiErr.Error(), "Retry the request or inspect the upstream Confluence response.")
-			return ExitError
-		default:
-			writeError(stderr, "ERROR", err.Error(), "Inspect the command inputs or retry with a smaller request.")
-			return ExitError
-		}
+		detail, code := classifyError(err)
+		writeError(stderr, detail.Code, detail.Message, detail.Hint)
+		return code
 	}
 
 	return ExitOK
 }
 
+// classifyError maps a command error to its ErrorEnvelope detail and exit code.
+func classifyError(err error) (ErrorDetail, int) {
+	var validationErr *ValidationError
+	var apiErr *confluence.APIError
+	switch {
+	case errors.As(err, &validationErr):
+		return ErrorDetail{Code: "VALIDATION", Message: validationErr.Message, Hint: validationErr.Hint}, ExitValidation
+	case errors.As(err, &apiErr):
+		if apiErr.StatusCode == 401 || apiErr.StatusCode == 403 {
+			return ErrorDetail{Code: "AUTH_FAILED", Message: apiErr.Error(), Hint: "Verify your Confluence credentials or rerun `confluence auth login`.", upstreamStatus: apiErr.StatusCode}, ExitAuth
+		}
+		return ErrorDetail{Code: "API_ERROR", Message: apiErr.Error(), Hint: "Retry the request or inspect the upstream Confluence response.", upstreamStatus: apiErr.StatusCode}, ExitError
+	default:
+		return ErrorDetail{Code: "ERROR", Message: err.Error(), Hint: "Inspect the command inputs or retry with a smaller request."}, ExitError
+	}
+}
+
 func commandNeedsClient(command string) bool {
-	return command != "version" && command != "auth login"
+	switch strings.Fields(command)[0] {
+	case "version", "auth", "config", "schema", "help", "cql":
+		return false
+	}
+	return true
+}
+
+// commandName drops positional placeholders such as <key> from a kong command.
+func commandName(command string) string {
+	parts := strings.Fields(command)
+	name := parts[:0]
+	for _, part := range parts {
+		if !strings.HasPrefix(part, "<") {
+			name = append(name, part)
+		}
+	}
+	return strings.Join(name, " ")
 }
 
 func commandHint(args []string) string {
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// commandContract ties a command to the success envelope it emits. Read
+// commands also carry the GET route serve exposes them on; {pageId}-style
+// wildcards fill the flag of the same camelCase name.
+type commandContract struct {
+	Command  string
+	ItemType string
+	Envelope reflect.Type
+	Route    string
+}
+
+// commandContracts is the registry behind `confluence schema`; the drift test
+// compares it with the published files in contract/schemas.
+var commandContracts = []commandContract{
+	{Command: "spaces list", ItemType: "space-summary", Envelope: reflect.TypeOf(ListEnvelope[SpaceSummary]{}), Route: "/spaces"},
+	{Command: "spaces get", ItemType: "space-detail", Envelope: reflect.TypeOf(ItemEnvelope[SpaceDetail]{}), Route: "/spaces/{spaceId}"},
+	{Command: "pages list", ItemType: "page-summary", Envelope: reflect.TypeOf(ListEnvelope[PageSummary]{}), Route: "/pages"},
+	{Command: "pages get", ItemType: "page-detail", Envelope: reflect.TypeOf(ItemEnvelope[PageDetail]{}), Route: "/pages/{pageId}"},
+	{Command: "pages get-many", ItemType: "page-detail", Envelope: reflect.TypeOf(ListEnvelope[PageDetail]{}), Route: "/pages/get-many"},
+	{Command: "pages ancestors", ItemType: "page-ancestor", Envelope: reflect.TypeOf(ListEnvelope[Breadcrumb]{}), Route: "/pages/{pageId}/ancestors"},
+	{Command: "pages descendants", ItemType: "page-descendant", Envelope: reflect.TypeOf(ListEnvelope[DescendantSummary]{}), Route: "/pages/{pageId}/descendants"},
+	{Command: "pages tree", ItemType: "page-tree", Envelope: reflect.TypeOf(ItemEnvelope[PageTree]{}), Route: "/pages/{pageId}/tree"},
+	{Command: "pages search", ItemType: "page-search-result", Envelope: reflect.TypeOf(ListEnvelope[SearchSummary]{}), Route: "/pages/search"},
+	{Command: "search", ItemType: "search-result", Envelope: reflect.TypeOf(ListEnvelope[ContentSummary]{}), Route: "/search"},
+	{Command: "cql lint", ItemType: "cql-lint", Envelope: reflect.TypeOf(ItemEnvelope[CQLLint]{})},
+	{Command: "auth login", ItemType: "auth-login", Envelope: reflect.TypeOf(ItemEnvelope[AuthLoginInfo]{})},
+	{Command: "config get", ItemType: "config-value", Envelope: reflect.TypeOf(ItemEnvelope[ConfigValue]{})},
+	{Command: "config set", ItemType: "config-value", Envelope: reflect.TypeOf(ItemEnvelope[ConfigValue]{})},
+	{Command: "config list", ItemType: "config-list", Envelope: reflect.TypeOf(ItemEnvelope[ConfigListing]{})},
+	{Command: "help", ItemType: "command-catalog", Envelope: reflect.TypeOf(ItemEnvelope[CommandCatalog]{})},
+	{Command: "version", ItemType: "version", Envelope: reflect.TypeOf(ItemEnvelope[VersionInfo]{}), Route: "/version"},
+}
+
+// readCommands are the commands with a serve route. Batch lines and MCP tools
+// run the same set, so a new read command only needs a Route here.
+func readCommands() []string {
+	var commands []string
+	for _, contract := range commandContracts {
+		if contract.Route != "" {
+			commands = append(commands, contract.Command)
+		}
+	}
+	return commands
+}
+
+func findCommandContract(command string) (commandContract, bool) {
+	for _, contract := range commandContracts {
+		if contract.Command == command {
+			return contract, true
+		}
+	}
+	return commandContract{}, false
+}
+
+// JSONSchema is the subset of JSON Schema 2020-12 the contracts need.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Const                string                 `json:"const,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// commandSchema builds the published schema for one command's envelope.
+func commandSchema(contract commandContract) *JSONSchema {
+	schema := documentSchema(contract.Envelope, "confluence "+contract.Command)
+	schema.ID = "https://github.com/Prisma-Labs-Dev/confluence-cli/contract/schemas/" + schemaFileName(contract.Command)
+	if meta := schema.Properties["schema"]; meta != nil {
+		meta.Properties["itemType"] = &JSONSchema{Type: "string", Const: contract.ItemType}
+	}
+	return schema
+}
+
+// errorSchema builds the published schema for the stderr error envelope.
+func errorSchema() *JSONSchema {
+	schema := documentSchema(reflect.TypeOf(ErrorEnvelope{}), "confluence error")
+	schema.ID = "https://github.com/Prisma-Labs-Dev/confluence-cli/contract/schemas/" + schemaFileName("error")
+	return schema
+}
+
+func schemaFileName(command string) string {
+	return strings.ReplaceAll(command, " ", "-") + ".schema.json"
+}
+
+func documentSchema(t reflect.Type, title string) *JSONSchema {
+	g := schemaGenerator{defs: map[string]*JSONSchema{}}
+	schema := g.structSchema(t)
+	schema.Schema = jsonSchemaDialect
+	schema.Title = title
+	if len(g.defs) > 0 {
+		schema.Defs = g.defs
+	}
+	return schema
+}
+
+// schemaGenerator maps Go types to JSON Schema. Named CLI structs below the
+// envelope go into $defs so recursive shapes such as PageTreeNode resolve.
+type schemaGenerator struct {
+	defs map[string]*JSONSchema
+}
+
+func (g schemaGenerator) typeSchema(t reflect.Type) *JSONSchema {
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return &JSONSchema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case t == reflect.TypeOf(Schema{}):
+		return g.structSchema(t) // inline so commandSchema can pin itemType
+	case t.Kind() == reflect.Slice:
+		return &JSONSchema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case t.Kind() == reflect.Struct:
+		name := t.Name()
+		if _, ok := g.defs[name]; !ok {
+			g.defs[name] = nil // reserve before recursing
+			g.defs[name] = g.structSchema(t)
+		}
+		return &JSONSchema{Ref: "#/$defs/" + name}
+	case t.Kind() == reflect.String:
+		return &JSONSchema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// omittable reports whether encoding/json can leave the field out. omitempty
+// never drops a struct value such as time.Time, so those stay required.
+func omittable(field reflect.StructField) bool {
+	return strings.Contains(field.Tag.Get("json"), ",omitempty") && field.Type.Kind() != reflect.Struct
+}
+
+func (g schemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	closed := false
+	schema := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}, AdditionalProperties: &closed}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := jsonName(field)
+		if name == "" {
+			continue
+		}
+		schema.Properties[name] = g.typeSchema(field.Type)
+		if !omittable(field) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
@@ -0,0 +1,37 @@
+package cli
+
+import "strings"
+
+type SchemaCmd struct {
+	Command []string `arg:"" optional:"" help:"Command path such as pages get, or error for the error envelope"`
+}
+
+// SchemaCatalog is the output of `confluence schema` without a command.
+type SchemaCatalog struct {
+	Commands map[string]*JSONSchema `json:"commands"`
+	Error    *JSONSchema            `json:"error"`
+}
+
+func (cmd *SchemaCmd) Run(app *App) error {
+	command := strings.Join(cmd.Command, " ")
+	switch command {
+	case "":
+		catalog := SchemaCatalog{Commands: map[string]*JSONSchema{}, Error: errorSchema()}
+		for _, contract := range commandContracts {
+			catalog.Commands[contract.Command] = commandSchema(contract)
+		}
+		return renderJSON(app.Stdout, catalog)
+	case "error":
+		return renderJSON(app.Stdout, errorSchema())
+	}
+
+	contract, ok := findCommandContract(command)
+	if !ok {
+		valid := make([]string, 0, len(commandContracts)+1)
+		for _, contract := range commandContracts {
+			valid = append(valid, contract.Command)
+		}
+		return validationErrorf(helpHint("schema"), "no output schema for %q; valid commands: %s, error", command, strings.Join(valid, ", "))
+	}
+	return renderJSON(app.Stdout, commandSchema(contract))
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+)
+
+var publishedSchemaDir = filepath.Join("..", "..", "contract", "schemas")
+
+// schemaExemptCommands print documents or protocol messages rather than
+// success envelopes.
+var schemaExemptCommands = map[string]bool{"schema": true, "batch": true, "mcp serve": true, "serve": true}
+
+// TestPublishedSchemasMatchContracts fails when the Go structs in contract.go
+// drift from contract/schemas. Regenerate with CONFLUENCE_SCHEMA_UPDATE=1.
+func TestPublishedSchemasMatchContracts(t *testing.T) {
+	want := map[string]*JSONSchema{"error.schema.json": errorSchema()}
+	for _, contract := range commandContracts {
+		want[schemaFileName(contract.Command)] = commandSchema(contract)
+	}
+
+	for name, schema := range want {
+		var generated bytes.Buffer
+		if err := renderJSON(&generated, schema); err != nil {
+			t.Fatalf("render %s: %v", name, err)
+		}
+		path := filepath.Join(publishedSchemaDir, name)
+		if os.Getenv("CONFLUENCE_SCHEMA_UPDATE") == "1" {
+			if err := os.WriteFile(path, generated.Bytes(), 0o644); err != nil {
+				t.Fatalf("write %s: %v", path, err)
+			}
+		}
+		published, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("missing published schema %s: %v", path, err)
+			continue
+		}
+		if !bytes.Equal(published, generated.Bytes()) {
+			t.Errorf("%s drifted from the Go contract structs; rerun with CONFLUENCE_SCHEMA_UPDATE=1\n--- published ---\n%s--- generated ---\n%s", name, published, generated.String())
+		}
+	}
+
+	entries, err := os.ReadDir(publishedSchemaDir)
+	if err != nil {
+		t.Fatalf("read %s: %v", publishedSchemaDir, err)
+	}
+	for _, entry := range entries {
+		if _, ok := want[entry.Name()]; !ok {
+			t.Errorf("published schema %s has no matching command contract", entry.Name())
+		}
+	}
+}
+
+func TestEveryCommandHasSchemaContract(t *testing.T) {
+	parser, err := kong.New(&CLI{})
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	var missing []string
+	for _, leaf := range parser.Model.Leaves(true) {
+		command := leaf.Path()
+		if schemaExemptCommands[command] {
+			continue
+		}
+		if _, ok := findCommandContract(command); !ok {
+			missing = append(missing, command)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		t.Fatalf("commands without a schema contract: %s", strings.Join(missing, ", "))
+	}
+}
+
+// TestSchemaRequiresStructsDespiteOmitempty covers time.Time fields tagged
+// omitempty, which encoding/json still always writes.
+func TestSchemaRequiresStructsDespiteOmitempty(t *testing.T) {
+	schema := documentSchema(reflect.TypeOf(PageDetail{}), "page detail")
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	if !required["createdAt"] || required["parentId"] || required["version"] {
+		t.Errorf("PageDetail required = %v, want createdAt but not parentId or version", schema.Required)
+	}
+}
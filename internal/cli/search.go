@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"time"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+)
+
+type SearchCmd struct {
+	Query    string   `help:"Search text to match in any content" exclusive:"mode"`
+	CQL      string   `help:"Raw CQL expression for advanced search" exclusive:"mode"`
+	Type     []string `help:"Only these types: page, blogpost, comment, attachment, space (query mode only)" placeholder:"TYPE,..."`
+	SpaceKey []string `help:"Only content in these space keys (query mode only)" placeholder:"KEY,..."`
+	Limit    int      `help:"Maximum number of results per page" default:"10"`
+	Cursor   string   `help:"Opaque cursor from the previous response"`
+	All      bool     `help:"Follow nextCursor through every remaining page"`
+}
+
+// ContentSummary is the CLI-owned typed search result shape.
+type ContentSummary struct {
+	ID           string          `json:"id"`
+	Type         string          `json:"type"`
+	Title        string          `json:"title"`
+	SpaceKey     string          `json:"spaceKey,omitempty"`
+	Container    *ContainerRef   `json:"container,omitempty"`
+	LastModified *time.Time      `json:"lastModified,omitempty"`
+	Excerpt      string          `json:"excerpt,omitempty"`
+	Highlights   []HighlightSpan `json:"highlights,omitempty"`
+	URL          string          `json:"url,omitempty"`
+}
+
+// ContainerRef is the page or blog post holding a comment or attachment.
+type ContainerRef struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+}
+
+func (cmd *SearchCmd) Run(app *App) error {
+	hint := helpHint("search")
+	if err := validateRange("limit", cmd.Limit, 1, maxListLimit, hint); err != nil {
+		return err
+	}
+	for _, contentType := range cmd.Type {
+		if err := validateEnum("type", contentType, confluence.ContentTypes, hint); err != nil {
+			return err
+		}
+	}
+	if (cmd.Query == "") == (cmd.CQL == "") {
+		return validationError("provide exactly one of --query or --cql", hint)
+	}
+	if cmd.CQL != "" {
+		if len(cmd.Type) > 0 || len(cmd.SpaceKey) > 0 {
+			return validationError("--type and --space-key cannot be combined with --cql", hint)
+		}
+		if err := checkCQL(app, cmd.CQL); err != nil {
+			return err
+		}
+	}
+
+	list := listPages[ContentSummary]{Limit: cmd.Limit, Cursor: cmd.Cursor, All: cmd.All, ItemType: "search-result", Fields: []string{"id", "type", "title", "spaceKey", "container", "lastModified", "excerpt", "highlights", "url"}, Plain: renderContentSearchPlain}
+	return renderListPages(app, list, func(cursor string) ([]ContentSummary, string, error) {
+		result, err := app.Client.Search(confluence.SearchOptions{
+			Query:     cmd.Query,
+			CQL:       cmd.CQL,
+			Types:     cmd.Type,
+			SpaceKeys: cmd.SpaceKey,
+			Limit:     cmd.Limit,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		items := make([]ContentSummary, len(result.Results))
+		for i, resultItem := range result.Results {
+			items[i] = newContentSummary(resultItem)
+		}
+		return items, result.NextCursor, nil
+	})
+}
+
+func newContentSummary(result confluence.ContentResult) ContentSummary {
+	summary := ContentSummary{
+		ID:           result.ID,
+		Type:         result.Type,
+		Title:        result.Title,
+		SpaceKey:     result.SpaceKey,
+		LastModified: optionalTime(result.LastModified),
+		Excerpt:      result.Excerpt,
+		URL:          result.URL,
+	}
+	if result.Container != nil {
+		summary.Container = &ContainerRef{ID: result.Container.ID, Type: result.Container.Type, Title: result.Container.Title}
+	}
+	summary.Highlights = newHighlightSpans(result.Highlights)
+	return summary
+}
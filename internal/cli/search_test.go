@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSearchCommand(t *testing.T) {
+	var gotCQL string
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		gotCQL = r.URL.Query().Get("cql")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[{"content":{"id":"501","type":"comment","container":{"id":"400","type":"page","title":"ADR 12"}},"title":"Re: ADR 12","entityType":"content"}],"_links":{}}`))
+	})
+
+	var stdout bytes.Buffer
+	detail, code := app.runInProcess([]string{"search", "--query", "postgres", "--type", "comment,attachment", "--space-key", "ENG", "--format=json"}, &stdout)
+	if code != ExitOK {
+		t.Fatalf("runInProcess = %d %+v", code, detail)
+	}
+	if want := `type in (comment,attachment) AND text ~ "postgres" AND space="ENG"`; gotCQL != want {
+		t.Errorf("cql = %q, want %q", gotCQL, want)
+	}
+	for _, want := range []string{`"type": "comment"`, `"container": {`, `"title": "ADR 12"`, `"itemType": "search-result"`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %s:\n%s", want, stdout.String())
+		}
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"search"}, want: "provide exactly one of --query or --cql"},
+		{args: []string{"search", "--query", "x", "--type", "folder"}, want: "type must be one of"},
+		{args: []string{"search", "--cql", "type=page", "--space-key", "ENG"}, want: "cannot be combined with --cql"},
+		{args: []string{"search", "--cql", "type=page AND"}, want: "invalid CQL at column 14"},
+	}
+	for _, tc := range tests {
+		detail, code := app.runInProcess(append(tc.args, "--format=json"), &stdout)
+		if code != ExitValidation || !strings.Contains(detail.Message, tc.want) {
+			t.Errorf("%v = %d %+v, want %s", tc.args, code, detail, tc.want)
+		}
+	}
+}
+
+func TestSearchOmitsUnknownLastModified(t *testing.T) {
+	app, _ := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[
+			{"space":{"id":98305,"key":"ENG","name":"Engineering"},"title":"Engineering","entityType":"space"},
+			{"content":{"id":"400","type":"page"},"title":"ADR 12","lastModified":"2024-05-01T10:00:00.000Z","entityType":"content"}
+		],"_links":{}}`))
+	})
+
+	var stdout bytes.Buffer
+	if detail, code := app.runInProcess([]string{"search", "--query", "engineering", "--format=ndjson"}, &stdout); code != ExitOK {
+		t.Fatalf("runInProcess = %d %+v", code, detail)
+	}
+	lines := strings.Split(stdout.String(), "\n")
+	if strings.Contains(lines[0], "lastModified") || !strings.Contains(lines[0], `"type":"space"`) {
+		t.Errorf("space result = %s, want no lastModified", lines[0])
+	}
+	if !strings.Contains(lines[1], `"lastModified":"2024-05-01T10:00:00Z"`) {
+		t.Errorf("page result = %s, want lastModified", lines[1])
+	}
+}
+
+func TestSearchPlainEmphasizesHighlights(t *testing.T) {
+	if got := emphasize("café and cafés", []HighlightSpan{{Start: 0, End: 4}, {Start: 9, End: 14}, {Start: 2, End: 3}}); got != "**café** and **cafés**" {
+		t.Errorf("emphasize = %q", got)
+	}
+
+	var out bytes.Buffer
+	renderContentSearchPlain(&out, []ContentSummary{{
+		ID: "501", Type: "comment", Title: "Re: ADR 12", SpaceKey: "ENG",
+		Container:  &ContainerRef{ID: "400", Type: "page", Title: "ADR 12"},
+		Excerpt:    "we chose Postgres",
+		Highlights: []HighlightSpan{{Start: 9, End: 17}},
+	}}, "")
+	want := "[comment] Re: ADR 12 (id:501, space:ENG, on page \"ADR 12\" id:400)\n  we chose **Postgres**\n"
+	if out.String() != want {
+		t.Errorf("plain output = %q, want %q", out.String(), want)
+	}
+}
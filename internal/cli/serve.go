@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alecthomas/kong"
+)
+
+type ServeCmd struct {
+	Listen        string        `help:"Address to listen on; keep it on loopback unless something else authenticates callers" default:"127.0.0.1:8787"`
+	ShutdownGrace time.Duration `name:"shutdown-grace" help:"How long SIGINT/SIGTERM waits for in-flight requests" default:"10s"`
+}
+
+// serveRoute maps an HTTP route to the command it runs. Path values fill the
+// flags named in PathFlags; query parameters fill the rest.
+type serveRoute struct {
+	Pattern   string
+	Command   string
+	PathFlags map[string]string
+}
+
+func (cmd *ServeCmd) Run(app *App) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", cmd.Listen)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", cmd.Listen, err)
+	}
+	return app.serveHTTP(ctx, listener, cmd.ShutdownGrace)
+}
+
+// serveHTTP serves the API until ctx is cancelled, then lets in-flight
+// requests finish for up to shutdownGrace.
+func (app *App) serveHTTP(ctx context.Context, listener net.Listener, shutdownGrace time.Duration) error {
+	logger := slog.New(slog.NewJSONHandler(app.Stderr, nil))
+	handler, err := app.httpHandler(logger)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	logger.Info("listening", "addr", listener.Addr().String())
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+	logger.Info("shutting down", "timeout", shutdownGrace.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown: %w", err)
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (app *App) httpHandler(logger *slog.Logger) (http.Handler, error) {
+	mux := http.NewServeMux()
+	var patterns []string
+	for _, contract := range commandContracts {
+		if contract.Route == "" {
+			continue
+		}
+		route := serveRoute{Pattern: "GET " + contract.Route, Command: contract.Command, PathFlags: map[string]string{}}
+		_, flags, err := commandFlags(app.kongCtx.Model, route.Command)
+		if err != nil {
+			return nil, fmt.Errorf("route %s: %w", route.Pattern, err)
+		}
+		params := map[string]*kong.Flag{}
+		for _, flag := range flags {
+			name := camelFlagName(flag.Name)
+			if strings.Contains(contract.Route, "{"+name+"}") {
+				route.PathFlags[name] = flag.Name
+				continue
+			}
+			params[name] = flag
+		}
+		mux.HandleFunc(route.Pattern, func(w http.ResponseWriter, r *http.Request) {
+			app.serveCommand(w, r, route, params)
+		})
+		patterns = append(patterns, route.Pattern)
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeHTTPError(w, http.StatusNotFound, ErrorDetail{
+			Code:    "VALIDATION",
+			Message: fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path),
+			Hint:    "Routes: " + strings.Join(patterns, ", "),
+		})
+	})
+	return logRequests(logger, mux), nil
+}
+
+func (app *App) serveCommand(w http.ResponseWriter, r *http.Request, route serveRoute, params map[string]*kong.Flag) {
+	args := strings.Fields(route.Command)
+	for value, flag := range route.PathFlags {
+		args = append(args, "--"+flag+"="+r.PathValue(value))
+	}
+	for name, values := range r.URL.Query() {
+		flag, ok := params[name]
+		if !ok {
+			writeHTTPError(w, http.StatusBadRequest, ErrorDetail{
+				Code:    "VALIDATION",
+				Message: fmt.Sprintf("unsupported query parameter %q for %s", name, route.Pattern),
+				Hint:    helpHint(route.Command),
+			})
+			return
+		}
+		for _, value := range values {
+			if value == "" && valueType(flag.Target.Type()) == "boolean" {
+				value = "true"
+			}
+			args = append(args, "--"+flag.Name+"="+value)
+		}
+	}
+	args = append(args, "--format=json")
+
+	var stdout bytes.Buffer
+	if detail, code := app.runInProcess(args, &stdout); code != ExitOK {
+		writeHTTPError(w, httpStatus(detail, code), detail)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	discardWrite(w.Write(stdout.Bytes()))
+}
+
+// httpStatus maps the CLI exit-code categories onto HTTP statuses. Upstream
+// 4xx statuses pass through, so a missing page stays a 404 rather than
+// looking like a gateway failure.
+func httpStatus(detail ErrorDetail, code int) int {
+	switch {
+	case code == ExitValidation:
+		return http.StatusBadRequest
+	case detail.upstreamStatus >= 400 && detail.upstreamStatus < 500:
+		return detail.upstreamStatus
+	case code == ExitAuth:
+		return http.StatusUnauthorized
+	case detail.Code == "API_ERROR":
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeHTTPError(w http.ResponseWriter, status int, detail ErrorDetail) {
+	b, _ := json.Marshal(ErrorEnvelope{Error: detail})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	discardWrite(w.Write(append(b, '\n')))
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// logRequests writes one JSON log line per request to stderr.
+func logRequests(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		logger.Info("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"durationMs", time.Since(start).Milliseconds(),
+		)
+	})
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	confluence "github.com/Prisma-Labs-Dev/confluence-cli"
+	"github.com/alecthomas/kong"
+)
+
+func newUpstreamTestApp(t *testing.T, upstream http.HandlerFunc) (*App, *bytes.Buffer) {
+	t.Helper()
+	srv := httptest.NewServer(upstream)
+	t.Cleanup(srv.Close)
+
+	var cli CLI
+	parser, err := kong.New(&cli, kong.Name("confluence"))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	ctx, err := parser.Parse([]string{"serve"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var stderr bytes.Buffer
+	return &App{
+		Client:  newClient(Credentials{URL: srv.URL, Email: "a@b.com", Token: "tok"}, 5*time.Second, io.Discard),
+		Stderr:  &stderr,
+		Format:  "json",
+		Version: "test-version",
+		config:  &ConfigFile{},
+		kongCtx: ctx,
+	}, &stderr
+}
+
+func TestServeRoutesMirrorCommands(t *testing.T) {
+	var gotBodyFormat string
+	app, stderr := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/pages/999") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"No page found"}]}`))
+			return
+		}
+		gotBodyFormat = r.URL.Query().Get("body-format")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"123","title":"Overview","spaceId":"S1","status":"current","version":{"number":7}}`))
+	})
+	handler, err := app.httpHandler(slog.New(slog.NewJSONHandler(stderr, nil)))
+	if err != nil {
+		t.Fatalf("httpHandler: %v", err)
+	}
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{path: "/pages/123?bodyFormat=view&fields=id,title", wantStatus: http.StatusOK, wantBody: `"itemType": "page-detail"`},
+		{path: "/pages/999", wantStatus: http.StatusNotFound, wantBody: `"code":"API_ERROR"`},
+		{path: "/pages/get-many", wantStatus: http.StatusBadRequest, wantBody: `provide --page-id`},
+		{path: "/pages/123?pageId=1", wantStatus: http.StatusBadRequest, wantBody: `unsupported query parameter \"pageId\"`},
+		{path: "/pages/123/tree?depth=abc", wantStatus: http.StatusBadRequest, wantBody: `"code":"VALIDATION"`},
+		{path: "/pages", wantStatus: http.StatusBadRequest, wantBody: `--space-id`},
+		{path: "/attachments", wantStatus: http.StatusNotFound, wantBody: `no route for GET /attachments`},
+		{path: "/version", wantStatus: http.StatusOK, wantBody: `"version": "test-version"`},
+	}
+	for _, tc := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if rec.Code != tc.wantStatus || !strings.Contains(rec.Body.String(), tc.wantBody) {
+			t.Errorf("GET %s = %d %s, want %d containing %s", tc.path, rec.Code, rec.Body.String(), tc.wantStatus, tc.wantBody)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("GET %s Content-Type = %q", tc.path, ct)
+		}
+	}
+	if gotBodyFormat != "view" {
+		t.Errorf("upstream body-format = %q, want view", gotBodyFormat)
+	}
+
+	var entry map[string]any
+	firstLine, _, _ := strings.Cut(stderr.String(), "\n")
+	if err := json.Unmarshal([]byte(firstLine), &entry); err != nil {
+		t.Fatalf("request log is not JSON: %v\n%s", err, stderr.String())
+	}
+	if entry["msg"] != "request" || entry["path"] != "/pages/123" || entry["status"] != float64(200) {
+		t.Errorf("request log = %v", entry)
+	}
+}
+
+func TestServeMountsEveryReadCommand(t *testing.T) {
+	app, stderr := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler, err := app.httpHandler(slog.New(slog.NewJSONHandler(stderr, nil)))
+	if err != nil {
+		t.Fatalf("httpHandler: %v", err)
+	}
+	for _, contract := range commandContracts {
+		if contract.Route == "" {
+			continue
+		}
+		path := strings.NewReplacer("{pageId}", "123", "{spaceId}", "456").Replace(contract.Route)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if strings.Contains(rec.Body.String(), "no route for") {
+			t.Errorf("%s: GET %s is not mounted", contract.Command, path)
+		}
+	}
+}
+
+func TestHTTPStatusPassesUpstreamClientErrors(t *testing.T) {
+	tests := []struct {
+		upstream int
+		want     int
+	}{
+		{upstream: http.StatusNotFound, want: http.StatusNotFound},
+		{upstream: http.StatusForbidden, want: http.StatusForbidden},
+		{upstream: http.StatusUnauthorized, want: http.StatusUnauthorized},
+		{upstream: http.StatusTooManyRequests, want: http.StatusTooManyRequests},
+		{upstream: http.StatusServiceUnavailable, want: http.StatusBadGateway},
+	}
+	for _, tc := range tests {
+		detail, code := classifyError(&confluence.APIError{StatusCode: tc.upstream, Message: "upstream"})
+		if got := httpStatus(detail, code); got != tc.want {
+			t.Errorf("upstream %d: httpStatus = %d, want %d", tc.upstream, got, tc.want)
+		}
+	}
+}
+
+func TestServeHTTPShutsDownGracefully(t *testing.T) {
+	app, stderr := newUpstreamTestApp(t, func(w http.ResponseWriter, r *http.Request) {})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- app.serveHTTP(ctx, listener, time.Second) }()
+
+	resp, err := http.Get("http://" + listener.Addr().String() + "/version")
+	if err != nil {
+		t.Fatalf("GET /version: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /version status = %d", resp.StatusCode)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("serveHTTP returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serveHTTP did not stop after cancellation")
+	}
+	if !strings.Contains(stderr.String(), `"msg":"shutting down"`) {
+		t.Errorf("missing shutdown log: %s", stderr.String())
+	}
+}
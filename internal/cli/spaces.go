@@ -2,29 +2,86 @@ package cli
 
 import confluence "github.com/Prisma-Labs-Dev/confluence-cli"
 
+var (
+	spaceTypes    = []string{"global", "personal"}
+	spaceStatuses = []string{"current", "archived"}
+	spaceSorts    = []string{"id", "-id", "key", "-key", "name", "-name"}
+)
+
 type SpacesListCmd struct {
-	Limit  int    `help:"Maximum number of results per page" default:"10"`
-	Cursor string `help:"Opaque cursor from the previous response"`
+	Limit     int      `help:"Maximum number of results per page" default:"10"`
+	Cursor    string   `help:"Opaque cursor from the previous response"`
+	Type      string   `help:"Only spaces of this type: global or personal"`
+	Status    string   `help:"Only spaces with this status: current or archived"`
+	SpaceKey  []string `name:"space-key" help:"Only these space keys" placeholder:"KEY,..."`
+	Label     []string `help:"Only spaces with any of these labels" placeholder:"LABEL,..."`
+	Favourite bool     `help:"Only spaces the caller marked as favourite"`
+	Sort      string   `help:"Sort order: id, key, or name; prefix - for descending"`
+	All       bool     `help:"Follow nextCursor through every remaining page"`
 }
 
 func (cmd *SpacesListCmd) Run(app *App) error {
-	if err := validateRange("limit", cmd.Limit, 1, maxListLimit, helpHint("spaces list")); err != nil {
+	hint := helpHint("spaces list")
+	if err := validateRange("limit", cmd.Limit, 1, maxListLimit, hint); err != nil {
 		return err
 	}
-
-	result, err := app.Client.ListSpaces(confluence.ListSpacesOptions{Limit: cmd.Limit, Cursor: cmd.Cursor})
-	if err != nil {
+	if err := validateEnum("type", cmd.Type, spaceTypes, hint); err != nil {
+		return err
+	}
+	if err := validateEnum("status", cmd.Status, spaceStatuses, hint); err != nil {
 		return err
 	}
+	if err := validateEnum("sort", cmd.Sort, spaceSorts, hint); err != nil {
+		return err
+	}
+	if cmd.Sort != "" && app.Flavor == confluence.FlavorServer {
+		return validationError("sort is not available with --flavor server; Data Center lists spaces by key", hint)
+	}
 
-	items := make([]SpaceSummary, len(result.Results))
-	for i, space := range result.Results {
-		items[i] = newSpaceSummary(space)
+	list := listPages[SpaceSummary]{Limit: cmd.Limit, Cursor: cmd.Cursor, All: cmd.All, ItemType: "space-summary", Fields: []string{"id", "key", "name", "type", "status"}, Plain: renderSpacesPlain}
+	return renderListPages(app, list, func(cursor string) ([]SpaceSummary, string, error) {
+		result, err := app.Client.ListSpaces(confluence.ListSpacesOptions{
+			Keys:      cmd.SpaceKey,
+			Type:      cmd.Type,
+			Status:    cmd.Status,
+			Labels:    cmd.Label,
+			Favourite: cmd.Favourite,
+			Sort:      cmd.Sort,
+			Limit:     cmd.Limit,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		items := make([]SpaceSummary, len(result.Results))
+		for i, space := range result.Results {
+			items[i] = newSpaceSummary(space)
+		}
+		return items, result.NextCursor, nil
+	})
+}
+
+type SpacesGetCmd struct {
+	SpaceID  string `help:"Space ID from spaces list output, or a space URL" exclusive:"space"`
+	SpaceKey string `help:"Space key such as ENG" exclusive:"space"`
+}
+
+func (cmd *SpacesGetCmd) Run(app *App) error {
+	if (cmd.SpaceID == "") == (cmd.SpaceKey == "") {
+		return validationError("provide exactly one of --space-id or --space-key", helpHint("spaces get"))
+	}
+
+	space, err := app.Client.GetSpace(confluence.GetSpaceOptions{SpaceID: cmd.SpaceID, SpaceKey: cmd.SpaceKey})
+	if err != nil {
+		return err
 	}
 
+	item := newSpaceDetail(space)
 	if app.IsPlain() {
-		renderSpacesPlain(app.Stdout, items, result.NextCursor)
+		renderSpacePlain(app.Stdout, item)
 		return nil
 	}
-	return renderJSON(app.Stdout, listEnvelope(items, cmd.Limit, result.NextCursor, "space-summary", []string{"id", "key", "name", "type", "status"}))
+	return app.renderEnvelope(itemEnvelope(item, "space-detail", spaceDetailFields))
 }
+
+var spaceDetailFields = []string{"id", "key", "name", "type", "status", "description", "homepageId", "labels", "createdAt"}
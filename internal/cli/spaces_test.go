@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpacesListValidatesFilters(t *testing.T) {
+	t.Setenv("CONFLUENCE_CONFIG_DIR", t.TempDir())
+	creds := []string{"--url", "https://example.atlassian.net", "--email", "a@b.com", "--token", "tok"}
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"spaces", "list", "--type", "team"}, want: `type must be one of: global, personal (got \"team\")`},
+		{args: []string{"spaces", "list", "--status", "deleted"}, want: "status must be one of: current, archived"},
+		{args: []string{"spaces", "list", "--sort", "created"}, want: "sort must be one of: id, -id, key, -key, name, -name"},
+		{args: []string{"--flavor", "server", "spaces", "list", "--sort", "name"}, want: "sort is not available with --flavor server"},
+	}
+	for _, tc := range tests {
+		_, stderr, code := runCLIForTest(t, append(append([]string{}, creds...), tc.args...), false)
+		if code != ExitValidation || !strings.Contains(stderr, tc.want) {
+			t.Errorf("%v: exit=%d stderr=%s, want %s", tc.args, code, stderr, tc.want)
+		}
+	}
+}
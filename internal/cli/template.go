@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// templateFuncs are the helpers available to --template and --template-file.
+var templateFuncs = template.FuncMap{
+	"md":       markdownEscape,
+	"truncate": truncateText,
+	"date":     formatDate,
+	"json":     templateJSON,
+}
+
+// loadTemplate parses the user template up front so syntax errors are
+// reported before any API call.
+func loadTemplate(text, file string) (*template.Template, error) {
+	if text != "" && file != "" {
+		return nil, fmt.Errorf("use either --template or --template-file, not both")
+	}
+	if file != "" {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read --template-file: %w", err)
+		}
+		text = string(b)
+	}
+	if text == "" {
+		return nil, fmt.Errorf("--format template requires --template or --template-file")
+	}
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renderTemplate executes tmpl against the same envelope struct that JSON
+// output serializes, so fields are addressed as .Results, .Item, .Page, and
+// .Schema.
+func renderTemplate(w io.Writer, tmpl *template.Template, envelope any, hint string) error {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, envelope); err != nil {
+		return validationErrorf(hint, "execute template: %v", err)
+	}
+	discardWrite(io.WriteString(w, b.String()))
+	return nil
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+func markdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// truncateText shortens s to at most n runes, marking the cut with an ellipsis.
+func truncateText(n int, s string) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if n == 1 {
+		return "…"
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// formatDate accepts a time.Time or an RFC 3339 string and formats it with a
+// Go layout; zero and unparseable values render as an empty string.
+func formatDate(layout string, value any) string {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v != nil {
+			t = *v
+		}
+	case string:
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return ""
+		}
+		t = parsed
+	}
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+func templateJSON(value any) (string, error) {
+	b, err := json.Marshal(value)
+	return string(b), err
+}
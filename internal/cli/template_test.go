@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderTemplateHelpers(t *testing.T) {
+	tmpl, err := loadTemplate(`{{range .Results}}- [{{md .Title}}]({{.URL}}) {{.Excerpt | truncate 8}}{{"\n"}}{{end}}{{.Page.NextCursor}}`, "")
+	if err != nil {
+		t.Fatalf("loadTemplate: %v", err)
+	}
+	envelope := listEnvelope([]SearchSummary{
+		{ID: "1", Title: "Deploy *prod* [v2]", URL: "/wiki/x", Excerpt: "Steps to deploy safely"},
+	}, 10, "next", "page-search-result", []string{"id", "title"})
+
+	var out strings.Builder
+	if err := renderTemplate(&out, tmpl, envelope, ""); err != nil {
+		t.Fatalf("renderTemplate: %v", err)
+	}
+	want := "- [Deploy \\*prod\\* \\[v2\\]](/wiki/x) Steps t…\nnext"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	if got := formatDate("2006-01-02", ts); got != "2026-03-04" {
+		t.Errorf("time.Time = %q", got)
+	}
+	if got := formatDate("Jan 2", "2026-03-04T05:06:07Z"); got != "Mar 4" {
+		t.Errorf("string = %q", got)
+	}
+	if got := formatDate("2006", time.Time{}); got != "" {
+		t.Errorf("zero = %q, want empty", got)
+	}
+}
+
+func TestLoadTemplateErrors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "out.tmpl")
+	if err := os.WriteFile(file, []byte("{{.Item.Version}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadTemplate("", file); err != nil {
+		t.Fatalf("template file: %v", err)
+	}
+
+	for name, tc := range map[string][2]string{
+		"missing": {"", ""},
+		"both":    {"x", file},
+		"syntax":  {"{{.Title", ""},
+	} {
+		if _, err := loadTemplate(tc[0], tc[1]); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	tmpl, _ := loadTemplate("{{.Nope}}", "")
+	err := renderTemplate(&strings.Builder{}, tmpl, itemEnvelope(VersionInfo{}, "version", nil), "")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("execute error = %v, want ValidationError", err)
+	}
+}
@@ -1,6 +1,10 @@
 package cli
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type ValidationError struct {
 	Message string
@@ -25,3 +29,27 @@ func validateRange(name string, value, min, max int, hint string) error {
 	}
 	return nil
 }
+
+func validateEnum(name, value string, allowed []string, hint string) error {
+	if value == "" {
+		return nil
+	}
+	for _, candidate := range allowed {
+		if value == candidate {
+			return nil
+		}
+	}
+	return validationErrorf(hint, "%s must be one of: %s (got %q)", name, strings.Join(allowed, ", "), value)
+}
+
+// parseDateFlag reads an optional YYYY-MM-DD flag value.
+func parseDateFlag(name, value, hint string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, validationErrorf(hint, "%s must be a date like 2024-01-31, got %q", name, value)
+	}
+	return date, nil
+}
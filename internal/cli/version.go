@@ -9,5 +9,5 @@ func (cmd *VersionCmd) Run(app *App) error {
 		discardWrite(fmt.Fprintf(app.Stdout, "confluence %s\n", app.Version))
 		return nil
 	}
-	return renderJSON(app.Stdout, itemEnvelope(VersionInfo{Version: app.Version}, "version", []string{"version"}))
+	return app.renderEnvelope(itemEnvelope(VersionInfo{Version: app.Version}, "version", []string{"version"}))
 }
@@ -0,0 +1,109 @@
+package cql
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLintAcceptsValidQueries(t *testing.T) {
+	tests := map[string][]string{
+		`type=page`:                           {"type"},
+		`space = "SC" AND title ~ "slotting"`: {"space", "title"},
+		`type=page AND space in ("ENG","O\"PS") AND label="runbook"`:                     {"type", "space", "label"},
+		`(creator = currentUser() OR contributor = "557058:abc") NOT label = archived`:   {"creator", "contributor", "label"},
+		`lastModified >= now("-4w") and text !~ 'draft'`:                                 {"lastmodified", "text"},
+		`ancestor=67890 AND id not in (1, 2) ORDER BY lastmodified desc, title`:          {"ancestor", "id"},
+		`NOT (type = blogpost) AND created < "2024-01-01" AND space.key IN (ENG)`:        {"type", "created", "space.key"},
+		`type=page AND space.id=98305 AND title="Say \"hi\"" ORDER BY created DESC`:      {"type", "space.id", "title"},
+		`title = "a\\" AND type=page`:                                                    {"title", "type"},
+		`content.property[metadata].key = x AND content.property[data].n > 2`:            {"content.property[metadata].key", "content.property[data].n"},
+		`siteSearch ~ "deploy" AND user.fullname ~ "Ada" AND favourite = currentUser()`:  {"sitesearch", "user.fullname", "favourite"},
+		`macro = jira AND mention = currentUser() AND watcher = "a" AND parent = 123456`: {"macro", "mention", "watcher", "parent"},
+	}
+	for query, wantFields := range tests {
+		result, err := Lint(query)
+		if err != nil {
+			t.Errorf("Lint(%q): %v", query, err)
+			continue
+		}
+		if !reflect.DeepEqual(result.Fields, wantFields) {
+			t.Errorf("Lint(%q).Fields = %v, want %v", query, result.Fields, wantFields)
+		}
+	}
+
+	result, err := Lint(`type=page ORDER BY lastmodified desc, title`)
+	if err != nil || !reflect.DeepEqual(result.OrderBy, []string{"lastmodified desc", "title"}) {
+		t.Errorf("OrderBy = %v, %v", result, err)
+	}
+}
+
+func TestLintReportsPositionAndSuggestion(t *testing.T) {
+	tests := []struct {
+		query      string
+		pos        int
+		message    string
+		suggestion string
+	}{
+		{query: `titel ~ "x"`, pos: 0, message: `unknown field "titel"`, suggestion: "did you mean title?"},
+		{query: `type=page AND lastmodifed > "2024-01-01"`, pos: 14, message: "unknown field", suggestion: "did you mean lastmodified?"},
+		{query: `text = "runbook"`, pos: 5, message: "operator = is not supported for text", suggestion: "text supports ~, !~"},
+		{query: `created ~ "2024"`, pos: 8, message: "operator ~ is not supported for created", suggestion: ">="},
+		{query: `title ~ "runbook`, pos: 8, message: "unterminated string", suggestion: `close the string with "`},
+		{query: `title ~ "run\"book`, pos: 8, message: "unterminated string", suggestion: `close the string with "`},
+		{query: `content.property[metadata.key = x`, pos: 16, message: "unterminated [", suggestion: "close the [ with ]"},
+		{query: `(type = page AND label = x`, pos: 26, message: "expected ) but found end of query", suggestion: "column 1"},
+		{query: `type = page && label = x`, pos: 12, message: `unexpected "&"`, suggestion: "AND and OR"},
+		{query: `type = page label = x`, pos: 12, message: `unexpected "label"`, suggestion: "AND or OR"},
+		{query: `type = page AND`, pos: 15, message: "expected a field but found end of query", suggestion: "start a clause"},
+		{query: `label in runbook`, pos: 9, message: "expected ( after in", suggestion: "list"},
+		{query: `id in (1 2)`, pos: 9, message: "expected , or )", suggestion: "commas"},
+		{query: `title`, pos: 5, message: "expected an operator after title", suggestion: "title supports"},
+		{query: `type = page ORDER lastmodified`, pos: 18, message: "expected BY after ORDER", suggestion: "ORDER BY"},
+		{query: `type = page ORDER BY created_at`, pos: 21, message: `unknown field "created_at"`, suggestion: "did you mean created?"},
+		{query: `type = page ORDER BY rank`, pos: 21, message: `unknown field "rank"`, suggestion: "known fields: ancestor,"},
+		{query: `   `, pos: 0, message: "empty query", suggestion: "type=page"},
+	}
+	for _, tc := range tests {
+		_, err := Lint(tc.query)
+		var lintErr *Error
+		if !errors.As(err, &lintErr) {
+			t.Errorf("Lint(%q) = %v, want *Error", tc.query, err)
+			continue
+		}
+		if lintErr.Pos != tc.pos || !strings.Contains(lintErr.Message, tc.message) || !strings.Contains(lintErr.Suggestion, tc.suggestion) {
+			t.Errorf("Lint(%q) = pos %d %q (%q), want pos %d containing %q (%q)", tc.query, lintErr.Pos, lintErr.Message, lintErr.Suggestion, tc.pos, tc.message, tc.suggestion)
+		}
+	}
+}
+
+func TestCheckWarnsOnUnknownFieldsAndOperators(t *testing.T) {
+	result, err := Check(`type = page AND customfield = x AND text = "runbook" ORDER BY rank`)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	var got []string
+	for _, warning := range result.Warnings {
+		got = append(got, warning.Message)
+	}
+	want := []string{`unknown field "customfield"`, "operator = is not supported for text", `unknown field "rank"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Warnings = %q, want %q", got, want)
+	}
+
+	if _, err := Check(`customfield = x AND`); err == nil || !strings.Contains(err.Error(), "expected a field") {
+		t.Errorf("Check syntax error = %v", err)
+	}
+	// Lint reports whichever problem comes first.
+	if _, err := Lint(`customfield = x AND`); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Errorf("Lint = %v, want the earlier unknown field", err)
+	}
+}
+
+func TestErrorReportsOneBasedColumn(t *testing.T) {
+	err := &Error{Pos: 4, Message: "unknown field"}
+	if got := err.Error(); got != "invalid CQL at column 5: unknown field" {
+		t.Errorf("Error() = %q", got)
+	}
+}
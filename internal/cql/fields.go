@@ -0,0 +1,127 @@
+package cql
+
+import (
+	"sort"
+	"strings"
+)
+
+var (
+	textOps  = []string{"~", "!~"}
+	titleOps = []string{"=", "!=", "~", "!~", "in", "not in"}
+	dateOps  = []string{"=", "!=", ">", ">=", "<", "<="}
+	exactOps = []string{"=", "!=", "in", "not in"}
+)
+
+// fieldOps lists the documented CQL fields, in lower case, with the
+// operators each one supports.
+var fieldOps = map[string][]string{
+	"ancestor":       exactOps,
+	"container":      exactOps,
+	"content":        exactOps,
+	"created":        dateOps,
+	"creator":        exactOps,
+	"contributor":    exactOps,
+	"favourite":      exactOps,
+	"favorite":       exactOps,
+	"id":             exactOps,
+	"label":          exactOps,
+	"lastmodified":   dateOps,
+	"macro":          exactOps,
+	"mention":        exactOps,
+	"parent":         exactOps,
+	"sitesearch":     textOps,
+	"space":          exactOps,
+	"space.category": exactOps,
+	"space.id":       exactOps,
+	"space.key":      exactOps,
+	"space.title":    titleOps,
+	"space.type":     exactOps,
+	"text":           textOps,
+	"title":          titleOps,
+	"type":           exactOps,
+	"user":           exactOps,
+	"user.accountid": exactOps,
+	"user.fullname":  titleOps,
+	"user.userkey":   exactOps,
+	"watcher":        exactOps,
+}
+
+// Fields returns the known CQL field names in sorted order.
+func Fields() []string {
+	names := make([]string, 0, len(fieldOps))
+	for name := range fieldOps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// propertyOps are the operators for content.property[...] fields, whose
+// value types are defined per property.
+var propertyOps = []string{"=", "!=", "~", "!~", ">", ">=", "<", "<=", "in", "not in"}
+
+func isPropertyField(field string) bool {
+	return strings.HasPrefix(field, "content.property[")
+}
+
+func isKnownField(field string) bool {
+	_, ok := fieldOps[field]
+	return ok || isPropertyField(field)
+}
+
+func fieldOperators(field string) []string {
+	if isPropertyField(field) {
+		return propertyOps
+	}
+	return fieldOps[field]
+}
+
+func operatorHint(field string) string {
+	if ops := fieldOperators(field); len(ops) > 0 {
+		return field + " supports " + strings.Join(ops, ", ")
+	}
+	return "use an operator such as =, ~, or in"
+}
+
+func supportsOp(field, op string) bool {
+	for _, candidate := range fieldOperators(field) {
+		if candidate == op {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestField proposes the closest known field for a misspelled one.
+func suggestField(name string) string {
+	best, bestDistance := "", 4
+	for _, field := range Fields() {
+		if d := editDistance(strings.ToLower(name), field); d < bestDistance {
+			best, bestDistance = field, d
+		}
+	}
+	if best != "" {
+		return "did you mean " + best + "?"
+	}
+	return "known fields: " + strings.Join(Fields(), ", ")
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
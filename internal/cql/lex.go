@@ -0,0 +1,121 @@
+// Package cql checks Confluence Query Language expressions locally so that
+// malformed queries fail with a position and a suggestion instead of a
+// generic upstream 400.
+package cql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Error is a lint failure. Pos is the zero-based byte offset of the problem.
+type Error struct {
+	Pos        int
+	Message    string
+	Suggestion string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid CQL at column %d: %s", e.Pos+1, e.Message)
+}
+
+func errorf(pos int, suggestion, format string, args ...any) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...), Suggestion: suggestion}
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOp
+	tokenEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// keyword reports whether the token is the given case-insensitive keyword.
+func (t token) keyword(word string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, word)
+}
+
+func (t token) describe() string {
+	if t.kind == tokenEOF {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			// A backslash escapes the next byte, so \\" ends a string with a
+			// literal backslash while \" keeps it open.
+			end, escaped := i+1, false
+			for ; end < len(input); end++ {
+				if escaped {
+					escaped = false
+					continue
+				}
+				if input[end] == c {
+					break
+				}
+				escaped = input[end] == '\\'
+			}
+			if end >= len(input) {
+				return nil, errorf(i, fmt.Sprintf("close the string with %c", c), "unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: input[i+1 : end], pos: i})
+			i = end + 1
+		case c == '!' || c == '>' || c == '<':
+			op := input[i : i+1]
+			if i+1 < len(input) && (input[i+1] == '=' || (c == '!' && input[i+1] == '~')) {
+				op = input[i : i+2]
+			}
+			if op == "!" {
+				return nil, errorf(i, "use NOT, !=, or !~", "unexpected \"!\"")
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		case strings.IndexByte("=~(),", c) >= 0:
+			tokens = append(tokens, token{kind: tokenOp, text: string(c), pos: i})
+			i++
+		case c == '&' || c == '|':
+			return nil, errorf(i, "use AND and OR to combine clauses", "unexpected %q", string(c))
+		case isWordByte(c):
+			end := i + 1
+			for end < len(input) && (isWordByte(input[end]) || input[end] == '[') {
+				// A bracketed segment such as content.property[metadata].key
+				// belongs to the field name.
+				if input[end] == '[' {
+					closing := strings.IndexByte(input[end:], ']')
+					if closing < 0 {
+						return nil, errorf(end, "close the [ with ]", "unterminated [")
+					}
+					end += closing
+				}
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: input[i:end], pos: i})
+			i = end
+		default:
+			return nil, errorf(i, "quote values that contain punctuation", "unexpected %q", string(c))
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+func isWordByte(c byte) bool {
+	r := rune(c)
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || c >= 0x80 || strings.IndexByte("._-:/@+*", c) >= 0
+}
@@ -0,0 +1,250 @@
+package cql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Result summarises a valid query.
+type Result struct {
+	// Fields are the distinct fields the query filters on, in order of use.
+	Fields []string
+	// OrderBy holds the ORDER BY terms, such as "lastmodified desc".
+	OrderBy []string
+	// Warnings are unknown fields and unsupported operators. The field list
+	// is not exhaustive, so Confluence may still accept the query.
+	Warnings []*Error
+}
+
+// Lint parses a CQL query and checks its syntax, fields, and operators. The
+// returned error is an *Error for the first problem of any kind.
+func Lint(query string) (*Result, error) {
+	result, err := Check(query)
+	var syntaxErr *Error
+	if errors.As(err, &syntaxErr) && result != nil && len(result.Warnings) > 0 && result.Warnings[0].Pos < syntaxErr.Pos {
+		return nil, result.Warnings[0]
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Warnings) > 0 {
+		return nil, result.Warnings[0]
+	}
+	return result, nil
+}
+
+// Check parses a CQL query and fails only on syntax errors, returning an
+// *Error. Unknown fields and operators are reported in Result.Warnings. On a
+// syntax error the Result holds the warnings found before it.
+func Check(query string) (*Result, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errorf(0, `for example: type=page AND title ~ "runbook"`, "empty query")
+	}
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, result: &Result{}}
+	if err := p.parseOr(); err != nil {
+		return p.result, err
+	}
+	if p.peek().keyword("order") {
+		if err := p.parseOrderBy(); err != nil {
+			return p.result, err
+		}
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return p.result, errorf(next.pos, "join clauses with AND or OR", "unexpected %s", next.describe())
+	}
+	return p.result, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	result *Result
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOp(text string) bool {
+	t := p.peek()
+	return t.kind == tokenOp && t.text == text
+}
+
+func (p *parser) parseOr() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+	for p.peek().keyword("or") {
+		p.next()
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseAnd also accepts binary NOT, which CQL reads as AND NOT.
+func (p *parser) parseAnd() error {
+	if err := p.parseUnary(); err != nil {
+		return err
+	}
+	for p.peek().keyword("and") || p.peek().keyword("not") {
+		p.next()
+		if err := p.parseUnary(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseUnary() error {
+	if p.peek().keyword("not") {
+		p.next()
+		return p.parseUnary()
+	}
+	if p.isOp("(") {
+		open := p.next()
+		if err := p.parseOr(); err != nil {
+			return err
+		}
+		if !p.isOp(")") {
+			return errorf(p.peek().pos, fmt.Sprintf("close the ( opened at column %d", open.pos+1), "expected ) but found %s", p.peek().describe())
+		}
+		p.next()
+		return nil
+	}
+	return p.parseClause()
+}
+
+func (p *parser) parseClause() error {
+	fieldToken := p.next()
+	if fieldToken.kind != tokenWord || isKeyword(fieldToken.text) {
+		return errorf(fieldToken.pos, `start a clause with a field, e.g. title ~ "runbook"`, "expected a field but found %s", fieldToken.describe())
+	}
+	field := strings.ToLower(fieldToken.text)
+	known := isKnownField(field)
+	if !known {
+		p.warn(errorf(fieldToken.pos, suggestField(field), "unknown field %q", fieldToken.text))
+	}
+	p.noteField(field)
+
+	opToken := p.next()
+	op := strings.ToLower(opToken.text)
+	switch {
+	case opToken.keyword("not") && p.peek().keyword("in"):
+		p.next()
+		op = "not in"
+	case opToken.keyword("in"):
+	case opToken.kind == tokenOp && op != "(" && op != ")" && op != ",":
+	default:
+		return errorf(opToken.pos, operatorHint(field), "expected an operator after %s but found %s", fieldToken.text, opToken.describe())
+	}
+	if known && !supportsOp(field, op) {
+		p.warn(errorf(opToken.pos, operatorHint(field), "operator %s is not supported for %s", op, field))
+	}
+
+	if op == "in" || op == "not in" {
+		if !p.isOp("(") {
+			return errorf(p.peek().pos, `write the values as a list, e.g. ("A","B")`, "expected ( after %s", op)
+		}
+		return p.parseList()
+	}
+	return p.parseValue()
+}
+
+func (p *parser) parseList() error {
+	p.next()
+	for {
+		if err := p.parseValue(); err != nil {
+			return err
+		}
+		if p.isOp(",") {
+			p.next()
+			continue
+		}
+		if p.isOp(")") {
+			p.next()
+			return nil
+		}
+		return errorf(p.peek().pos, "separate list values with commas and close the list with )", "expected , or ) but found %s", p.peek().describe())
+	}
+}
+
+// parseValue reads a quoted string, a bare word, or a function call such as
+// currentUser() or now("-4w").
+func (p *parser) parseValue() error {
+	value := p.next()
+	switch {
+	case value.kind == tokenString:
+		return nil
+	case value.kind == tokenWord && !isKeyword(value.text):
+		if !p.isOp("(") {
+			return nil
+		}
+		if closing := p.tokens[p.pos+1]; closing.kind == tokenOp && closing.text == ")" {
+			p.pos += 2
+			return nil
+		}
+		return p.parseList()
+	}
+	return errorf(value.pos, `quote the value, e.g. "runbook"`, "expected a value but found %s", value.describe())
+}
+
+func (p *parser) parseOrderBy() error {
+	p.next()
+	if !p.peek().keyword("by") {
+		return errorf(p.peek().pos, "write ORDER BY <field>", "expected BY after ORDER but found %s", p.peek().describe())
+	}
+	p.next()
+	for {
+		fieldToken := p.next()
+		field := strings.ToLower(fieldToken.text)
+		if fieldToken.kind != tokenWord || isKeyword(field) {
+			return errorf(fieldToken.pos, "write ORDER BY <field> [asc|desc]", "expected a field but found %s", fieldToken.describe())
+		}
+		if !isKnownField(field) {
+			p.warn(errorf(fieldToken.pos, suggestField(field), "unknown field %q", fieldToken.text))
+		}
+		term := field
+		if p.peek().keyword("asc") || p.peek().keyword("desc") {
+			term += " " + strings.ToLower(p.next().text)
+		}
+		p.result.OrderBy = append(p.result.OrderBy, term)
+		if !p.isOp(",") {
+			return nil
+		}
+		p.next()
+	}
+}
+
+func (p *parser) warn(err *Error) {
+	p.result.Warnings = append(p.result.Warnings, err)
+}
+
+func (p *parser) noteField(field string) {
+	for _, seen := range p.result.Fields {
+		if seen == field {
+			return
+		}
+	}
+	p.result.Fields = append(p.result.Fields, field)
+}
+
+func isKeyword(word string) bool {
+	switch strings.ToLower(word) {
+	case "and", "or", "not", "in", "order", "by":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package confluence
+
+import "fmt"
+
+// FindSpaceID returns the ID of the space with the given key, or "" when no
+// such space is visible to the caller.
+func (c *Client) FindSpaceID(key string) (string, error) {
+	result, err := c.ListSpaces(ListSpacesOptions{Keys: []string{key}, Limit: 1})
+	if err != nil {
+		return "", err
+	}
+	for _, space := range result.Results {
+		if space.Key == key {
+			return space.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// FindPageID returns the ID of the page with exactly this title in the space
+// with this key, or "" when there is none.
+func (c *Client) FindPageID(spaceKey, title string) (string, error) {
+	result, err := c.SearchPages(PageSearchOptions{CQL: pageTitleCQL(spaceKey, title), Limit: 1})
+	if err != nil {
+		return "", err
+	}
+	if len(result.Results) == 0 {
+		return "", nil
+	}
+	return result.Results[0].ID, nil
+}
+
+// pageTitleCQL matches the page with exactly this title in one space.
+func pageTitleCQL(spaceKey, title string) string {
+	return fmt.Sprintf(`type=page AND space="%s" AND title="%s"`, escapeCQL(spaceKey), escapeCQL(title))
+}
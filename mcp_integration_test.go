@@ -0,0 +1,83 @@
+package confluence_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMCPServeToolsOverStdio_Integration(t *testing.T) {
+	srv := newBinaryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/pages/999999") {
+			w.WriteHeader(http.StatusNotFound)
+			writeFixtureResponse(t, w, "error_404.json")
+			return
+		}
+		writeJSONResponse(w, []byte(`{"id":"123","title":"Overview","spaceId":"S1","status":"current","version":{"number":7}}`))
+	})
+	defer srv.Close()
+
+	tmp := t.TempDir()
+	binPath := buildBinary(t, tmp)
+	env := envForIntegration(filepath.Join(tmp, "config"))
+
+	stdin := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"0"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"pages_get","arguments":{"page_id":"123","fields":["id","title"]}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"pages_get","arguments":{"page_id":"999999"}}}`,
+	}, "\n") + "\n"
+	stdout, stderr, err := runBinary(binPath, []string{"--url", srv.URL, "--email", "a@b.com", "--token", "tok", "mcp", "serve"}, stdin, env...)
+	if err != nil {
+		t.Fatalf("mcp serve failed: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 responses (notification gets none), got %d:\n%s", len(lines), stdout)
+	}
+
+	var initialize struct {
+		Result struct {
+			ProtocolVersion string `json:"protocolVersion"`
+			ServerInfo      struct {
+				Name string `json:"name"`
+			} `json:"serverInfo"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &initialize); err != nil {
+		t.Fatalf("parse initialize: %v\n%s", err, lines[0])
+	}
+	if initialize.Result.ProtocolVersion != "2025-03-26" || initialize.Result.ServerInfo.Name != "confluence" {
+		t.Fatalf("unexpected initialize result: %s", lines[0])
+	}
+
+	type callResponse struct {
+		ID     int `json:"id"`
+		Result struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+			StructuredContent json.RawMessage `json:"structuredContent"`
+			IsError           bool            `json:"isError"`
+		} `json:"result"`
+	}
+	var success callResponse
+	if err := json.Unmarshal([]byte(lines[1]), &success); err != nil {
+		t.Fatalf("parse tools/call: %v\n%s", err, lines[1])
+	}
+	want := `{"item":{"id":"123","title":"Overview"},"schema":{"itemType":"page-detail","fields":["id","title"]}}`
+	if success.ID != 2 || success.Result.IsError || success.Result.Content[0].Text != want || string(success.Result.StructuredContent) != want {
+		t.Fatalf("pages_get result = %s, want envelope %s", lines[1], want)
+	}
+
+	var failure callResponse
+	if err := json.Unmarshal([]byte(lines[2]), &failure); err != nil {
+		t.Fatalf("parse tools/call error: %v\n%s", err, lines[2])
+	}
+	if !failure.Result.IsError || !strings.Contains(failure.Result.Content[0].Text, `"code":"API_ERROR"`) {
+		t.Fatalf("expected API_ERROR tool error, got %s", lines[2])
+	}
+}
@@ -5,20 +5,43 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type ListPagesOptions struct {
 	SpaceID string
-	Limit   int
-	Cursor  string
-	Sort    string
+	Status  string // "current", "archived", or "trashed"
+	Title   string // exact title
+	// Date windows are inclusive of After and exclusive of Before. v2 has no
+	// date filters, so setting any of them lists pages through CQL search.
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	Limit          int
+	Cursor         string
+	Sort           string
 }
 
 func (c *Client) ListPages(opts ListPagesOptions) (*ListResult[Page], error) {
+	if c.isServer() {
+		return c.listPagesServer(opts)
+	}
+	if opts.hasDateWindow() {
+		return c.listPagesCQL(opts)
+	}
+
 	query := url.Values{}
 	if opts.SpaceID != "" {
 		query.Set("space-id", opts.SpaceID)
 	}
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+	if opts.Title != "" {
+		query.Set("title", opts.Title)
+	}
 	if opts.Limit > 0 {
 		query.Set("limit", strconv.Itoa(opts.Limit))
 	}
@@ -56,6 +79,10 @@ type GetPageOptions struct {
 }
 
 func (c *Client) GetPage(opts GetPageOptions) (*Page, error) {
+	if c.isServer() {
+		return c.getPageServer(opts)
+	}
+
 	query := url.Values{}
 	if opts.BodyFormat != "" {
 		query.Set("body-format", opts.BodyFormat)
@@ -81,6 +108,10 @@ type GetPageChildrenOptions struct {
 }
 
 func (c *Client) GetPageChildren(opts GetPageChildrenOptions) (*ListResult[Page], error) {
+	if c.isServer() {
+		return c.getPageChildrenServer(opts)
+	}
+
 	query := url.Values{}
 	if opts.Limit > 0 {
 		query.Set("limit", strconv.Itoa(opts.Limit))
@@ -109,3 +140,106 @@ func (c *Client) GetPageChildren(opts GetPageChildrenOptions) (*ListResult[Page]
 		NextCursor: extractCursor(raw.Links.Next),
 	}, nil
 }
+
+// maxPageIDsPerRequest is the v2 cap on both the id filter and limit.
+const maxPageIDsPerRequest = 250
+
+type GetPagesOptions struct {
+	PageIDs    []string
+	BodyFormat string // "view", "storage", "atlas_doc_format"
+}
+
+// GetPagesResult holds the pages found, in request order, and the requested
+// IDs that no page was returned for.
+type GetPagesResult struct {
+	Pages    []Page
+	NotFound []string
+}
+
+// GetPages fetches many pages with the id filter, chunking large ID lists.
+// Missing, deleted, or inaccessible pages are reported in NotFound instead of
+// failing the call.
+func (c *Client) GetPages(opts GetPagesOptions) (*GetPagesResult, error) {
+	ids := make([]string, 0, len(opts.PageIDs))
+	seen := map[string]bool{}
+	for _, id := range opts.PageIDs {
+		if id = strings.TrimSpace(id); id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	// Page IDs are numeric; anything else cannot match and would make the
+	// upstream filter reject the whole chunk.
+	var lookup []string
+	for _, id := range ids {
+		if _, err := strconv.ParseUint(id, 10, 64); err == nil {
+			lookup = append(lookup, id)
+		}
+	}
+
+	chunkSize := maxPageIDsPerRequest
+	if c.isServer() {
+		chunkSize = serverPageIDsPerRequest
+	}
+	found := map[string]Page{}
+	for start := 0; start < len(lookup); start += chunkSize {
+		chunk := lookup[start:min(start+chunkSize, len(lookup))]
+		var pages []Page
+		var err error
+		if c.isServer() {
+			pages, err = c.getPagesServer(chunk, opts.BodyFormat)
+		} else {
+			pages, err = c.getPagesChunk(chunk, opts.BodyFormat)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, page := range pages {
+			found[page.ID] = page
+		}
+	}
+
+	result := &GetPagesResult{Pages: []Page{}}
+	for _, id := range ids {
+		if page, ok := found[id]; ok {
+			result.Pages = append(result.Pages, page)
+		} else {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) getPagesChunk(ids []string, bodyFormat string) ([]Page, error) {
+	query := url.Values{}
+	query.Set("id", strings.Join(ids, ","))
+	query.Set("limit", strconv.Itoa(len(ids)))
+	if bodyFormat != "" {
+		query.Set("body-format", bodyFormat)
+	}
+
+	var pages []Page
+	for {
+		body, err := c.do("GET", "/pages", query)
+		if err != nil {
+			return nil, fmt.Errorf("getting pages: %w", err)
+		}
+
+		var raw paginatedResponse
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("parsing pages response: %w", err)
+		}
+		var chunk []Page
+		if err := json.Unmarshal(raw.Results, &chunk); err != nil {
+			return nil, fmt.Errorf("parsing pages: %w", err)
+		}
+		pages = append(pages, chunk...)
+
+		cursor := extractCursor(raw.Links.Next)
+		if cursor == "" {
+			return pages, nil
+		}
+		query.Set("cursor", cursor)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type PageSearchOptions struct {
@@ -14,15 +15,36 @@ type PageSearchOptions struct {
 	TitleOnly bool
 	SpaceID   string
 	SpaceKey  string
-	Limit     int
-	Cursor    string
+	SpaceKeys []string
+	// Structured filters compose with Query, or stand alone without it.
+	Labels         []string // every label must be present
+	Creator        string   // account ID (username on Data Center), or "me"
+	Contributor    string   // account ID (username on Data Center), or "me"
+	Ancestor       string   // page ID anywhere above the result
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	OrderBy        string // created, lastmodified, or title; prefix - for descending
+	// WithSpace and WithAncestors expand each hit with its space and
+	// breadcrumb trail so callers do not need a page fetch per result.
+	WithSpace     bool
+	WithAncestors bool
+	Limit         int
+	Cursor        string
 }
 
+// searchOrderFields are the CQL fields --order-by accepts.
+var searchOrderFields = map[string]bool{"created": true, "lastmodified": true, "title": true}
+
 func (c *Client) SearchPages(opts PageSearchOptions) (*ListResult[SearchResult], error) {
 	cql, err := buildPageSearchCQL(opts)
 	if err != nil {
 		return nil, err
 	}
+	if c.isServer() {
+		return c.searchPagesServer(cql, opts)
+	}
 
 	query := url.Values{}
 	query.Set("cql", cql)
@@ -32,6 +54,16 @@ func (c *Client) SearchPages(opts PageSearchOptions) (*ListResult[SearchResult],
 	if opts.Cursor != "" {
 		query.Set("cursor", opts.Cursor)
 	}
+	var expand []string
+	if opts.WithSpace {
+		expand = append(expand, "content.space")
+	}
+	if opts.WithAncestors {
+		expand = append(expand, "content.ancestors")
+	}
+	if len(expand) > 0 {
+		query.Set("expand", strings.Join(expand, ","))
+	}
 
 	body, err := c.doV1("GET", "/search", query)
 	if err != nil {
@@ -41,15 +73,15 @@ func (c *Client) SearchPages(opts PageSearchOptions) (*ListResult[SearchResult],
 	var raw struct {
 		Results []struct {
 			Content struct {
-				ID    string `json:"id"`
-				Title string `json:"title"`
-				Type  string `json:"type"`
-				Space *struct {
-					ID string `json:"id"`
-				} `json:"space,omitempty"`
+				ID        string       `json:"id"`
+				Title     string       `json:"title"`
+				Type      string       `json:"type"`
+				Space     *searchSpace `json:"space,omitempty"`
+				Ancestors []ContentRef `json:"ancestors,omitempty"`
 			} `json:"content"`
-			Excerpt string `json:"excerpt"`
-			URL     string `json:"url"`
+			Excerpt      string `json:"excerpt"`
+			URL          string `json:"url"`
+			LastModified string `json:"lastModified"`
 		} `json:"results"`
 		Links struct {
 			Next string `json:"next"`
@@ -62,15 +94,19 @@ func (c *Client) SearchPages(opts PageSearchOptions) (*ListResult[SearchResult],
 	results := make([]SearchResult, len(raw.Results))
 	for i, result := range raw.Results {
 		results[i] = SearchResult{
-			ID:      result.Content.ID,
-			Title:   result.Content.Title,
-			Type:    result.Content.Type,
-			Excerpt: result.Excerpt,
-			URL:     result.URL,
+			ID:    result.Content.ID,
+			Title: result.Content.Title,
+			Type:  result.Content.Type,
+			URL:   result.URL,
 		}
-		if result.Content.Space != nil {
-			results[i].SpaceID = result.Content.Space.ID
+		results[i].Excerpt, results[i].Highlights = cleanExcerpt(result.Excerpt)
+		results[i].LastModified, _ = time.Parse(time.RFC3339, result.LastModified)
+		if space := result.Content.Space; space != nil {
+			results[i].SpaceID = space.ID.String()
+			results[i].SpaceKey = space.Key
+			results[i].SpaceName = space.Name
 		}
+		results[i].Ancestors = result.Content.Ancestors
 	}
 
 	return &ListResult[SearchResult]{
@@ -83,7 +119,10 @@ func buildPageSearchCQL(opts PageSearchOptions) (string, error) {
 	rawCQL := strings.TrimSpace(opts.CQL)
 	query := strings.TrimSpace(opts.Query)
 	spaceID := strings.TrimSpace(opts.SpaceID)
-	spaceKey := strings.TrimSpace(opts.SpaceKey)
+	spaceKeys := opts.SpaceKeys
+	if key := strings.TrimSpace(opts.SpaceKey); key != "" {
+		spaceKeys = append([]string{key}, spaceKeys...)
+	}
 
 	if rawCQL != "" {
 		if query != "" {
@@ -92,36 +131,102 @@ func buildPageSearchCQL(opts PageSearchOptions) (string, error) {
 		if opts.TitleOnly {
 			return "", fmt.Errorf("title-only cannot be combined with raw cql")
 		}
-		if spaceID != "" || spaceKey != "" {
+		if spaceID != "" || len(spaceKeys) > 0 {
 			return "", fmt.Errorf("space-id and space-key cannot be combined with raw cql")
 		}
+		if opts.hasFilters() {
+			return "", fmt.Errorf("structured filters cannot be combined with raw cql")
+		}
 		return rawCQL, nil
 	}
 
-	if query == "" {
-		return "", fmt.Errorf("search query or cql is required")
+	if query == "" && !opts.hasFilters() {
+		return "", fmt.Errorf("search query, structured filter, or cql is required")
 	}
-	if spaceID != "" && spaceKey != "" {
+	if spaceID != "" && len(spaceKeys) > 0 {
 		return "", fmt.Errorf("space-id and space-key are mutually exclusive")
 	}
 
-	field := "text"
-	if opts.TitleOnly {
-		field = "title"
+	clauses := []string{"type=page"}
+	if query != "" {
+		field := "text"
+		if opts.TitleOnly {
+			field = "title"
+		}
+		clauses = append(clauses, fmt.Sprintf("%s ~ \"%s\"", field, escapeCQL(query)))
+	} else if opts.TitleOnly {
+		return "", fmt.Errorf("title-only requires a search query")
 	}
-
-	cql := fmt.Sprintf("type=page AND %s ~ \"%s\"", field, escapeCQL(query))
 	if spaceID != "" {
-		cql += fmt.Sprintf(" AND space.id=%s", spaceID)
+		if _, err := strconv.ParseUint(spaceID, 10, 64); err != nil {
+			return "", fmt.Errorf("space-id must be a numeric space ID, got %q", spaceID)
+		}
+		clauses = append(clauses, fmt.Sprintf("space.id=%s", spaceID))
+	}
+	switch len(spaceKeys) {
+	case 0:
+	case 1:
+		clauses = append(clauses, fmt.Sprintf("space=\"%s\"", escapeCQL(spaceKeys[0])))
+	default:
+		clauses = append(clauses, "space in ("+quoteCQLList(spaceKeys)+")")
+	}
+	for _, label := range opts.Labels {
+		clauses = append(clauses, fmt.Sprintf("label=\"%s\"", escapeCQL(label)))
+	}
+	if opts.Creator != "" {
+		clauses = append(clauses, "creator="+cqlUser(opts.Creator))
 	}
-	if spaceKey != "" {
-		cql += fmt.Sprintf(" AND space=\"%s\"", escapeCQL(spaceKey))
+	if opts.Contributor != "" {
+		clauses = append(clauses, "contributor="+cqlUser(opts.Contributor))
+	}
+	if ancestor := strings.TrimSpace(opts.Ancestor); ancestor != "" {
+		if _, err := strconv.ParseUint(ancestor, 10, 64); err != nil {
+			return "", fmt.Errorf("ancestor must be a numeric page ID, got %q", opts.Ancestor)
+		}
+		clauses = append(clauses, "ancestor="+ancestor)
+	}
+	clauses = append(clauses, dateWindowCQL(opts.CreatedAfter, opts.CreatedBefore, opts.ModifiedAfter, opts.ModifiedBefore)...)
+
+	cql := strings.Join(clauses, " AND ")
+	if opts.OrderBy != "" {
+		field, desc := strings.CutPrefix(opts.OrderBy, "-")
+		if !searchOrderFields[field] {
+			return "", fmt.Errorf("unsupported order-by %q; use created, lastmodified, or title with an optional - prefix", opts.OrderBy)
+		}
+		cql += " ORDER BY " + field
+		if desc {
+			cql += " desc"
+		}
 	}
 	return cql, nil
 }
 
+func (opts PageSearchOptions) hasFilters() bool {
+	return len(opts.Labels) > 0 || opts.Creator != "" || opts.Contributor != "" || opts.Ancestor != "" ||
+		!opts.CreatedAfter.IsZero() || !opts.CreatedBefore.IsZero() ||
+		!opts.ModifiedAfter.IsZero() || !opts.ModifiedBefore.IsZero() || opts.OrderBy != ""
+}
+
+// cqlUser quotes an account ID or username; "me" becomes currentUser().
+func cqlUser(user string) string {
+	if user == "me" {
+		return "currentUser()"
+	}
+	return fmt.Sprintf("\"%s\"", escapeCQL(user))
+}
+
+func quoteCQLList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("\"%s\"", escapeCQL(value))
+	}
+	return strings.Join(quoted, ",")
+}
+
+// escapeCQL escapes a value for a double-quoted CQL string: backslashes first,
+// then quotes, so a value cannot end the string early.
 func escapeCQL(value string) string {
 	value = strings.ReplaceAll(value, `\`, `\\`)
-	value = strings.ReplaceAll(value, `"`, `\\"`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
 	return value
 }
@@ -1,6 +1,15 @@
 package confluence
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Prisma-Labs-Dev/confluence-cli/internal/cql"
+)
 
 func TestBuildPageSearchCQL(t *testing.T) {
 	t.Run("query with space key", func(t *testing.T) {
@@ -31,4 +40,254 @@ func TestBuildPageSearchCQL(t *testing.T) {
 			t.Fatal("expected conflict error")
 		}
 	})
+
+	t.Run("structured filters", func(t *testing.T) {
+		tests := []struct {
+			name string
+			opts PageSearchOptions
+			want string
+		}{
+			{
+				name: "labels and repeated space keys",
+				opts: PageSearchOptions{Query: "deploy", SpaceKeys: []string{"ENG", `O"PS`}, Labels: []string{"runbook", "prod"}},
+				want: `type=page AND text ~ "deploy" AND space in ("ENG","O\"PS") AND label="runbook" AND label="prod"`,
+			},
+			{
+				name: "people without a query",
+				opts: PageSearchOptions{Creator: "557058:abc", Contributor: "me"},
+				want: `type=page AND creator="557058:abc" AND contributor=currentUser()`,
+			},
+			{
+				name: "ancestor, dates, and order",
+				opts: PageSearchOptions{
+					Ancestor:      "67890",
+					ModifiedAfter: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					CreatedBefore: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					OrderBy:       "-lastmodified",
+				},
+				want: `type=page AND ancestor=67890 AND created < "2024-01-01" AND lastmodified >= "2024-06-01" ORDER BY lastmodified desc`,
+			},
+			{
+				name: "single space key keeps equality",
+				opts: PageSearchOptions{SpaceKey: "SC", OrderBy: "title"},
+				want: `type=page AND space="SC" ORDER BY title`,
+			},
+		}
+		for _, tc := range tests {
+			cql, err := buildPageSearchCQL(tc.opts)
+			if err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			if cql != tc.want {
+				t.Errorf("%s: cql = %q, want %q", tc.name, cql, tc.want)
+			}
+		}
+	})
+
+	t.Run("structured filter errors", func(t *testing.T) {
+		for name, opts := range map[string]PageSearchOptions{
+			"filters with raw cql": {CQL: "type=page", Labels: []string{"x"}},
+			"non-numeric ancestor": {Ancestor: "root"},
+			"non-numeric space ID": {Query: "x", SpaceID: "1 OR type=blogpost"},
+			"unknown order":        {Query: "x", OrderBy: "created desc"},
+			"nothing to search":    {},
+		} {
+			if _, err := buildPageSearchCQL(opts); err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+	})
+}
+
+// hostileCQLValue would close its string and add a mention clause if quotes
+// or backslashes were escaped wrongly.
+const hostileCQLValue = `a\" OR mention="X\`
+
+func TestBuiltPageSearchCQLPassesCheck(t *testing.T) {
+	query, err := buildPageSearchCQL(PageSearchOptions{
+		Query:       hostileCQLValue,
+		SpaceKeys:   []string{"ENG", hostileCQLValue},
+		Labels:      []string{hostileCQLValue},
+		Creator:     hostileCQLValue,
+		Contributor: hostileCQLValue,
+	})
+	if err != nil {
+		t.Fatalf("buildPageSearchCQL: %v", err)
+	}
+	checkBuiltCQL(t, query, "type", "text", "space", "label", "creator", "contributor")
+}
+
+func TestBuiltCQLPassesCheck(t *testing.T) {
+	query, err := buildSearchCQL(SearchOptions{Query: hostileCQLValue, SpaceKeys: []string{hostileCQLValue}})
+	if err != nil {
+		t.Fatalf("buildSearchCQL: %v", err)
+	}
+	checkBuiltCQL(t, query, "text", "space")
+
+	query, err = buildSearchCQL(SearchOptions{Query: hostileCQLValue, Types: []string{"page", "comment"}, SpaceKeys: []string{"ENG", hostileCQLValue}})
+	if err != nil {
+		t.Fatalf("buildSearchCQL: %v", err)
+	}
+	checkBuiltCQL(t, query, "type", "text", "space")
+
+	query, err = pageListCQL(ListPagesOptions{
+		SpaceID:      "98305",
+		Title:        hostileCQLValue,
+		CreatedAfter: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sort:         "-modified-date",
+	})
+	if err != nil {
+		t.Fatalf("pageListCQL: %v", err)
+	}
+	checkBuiltCQL(t, query, "type", "space.id", "title", "created")
+
+	checkBuiltCQL(t, pageTitleCQL(hostileCQLValue, hostileCQLValue), "type", "space", "title")
+}
+
+// checkBuiltCQL asserts that query parses and filters on exactly the fields
+// the builder wrote, none of them smuggled in through a value.
+func checkBuiltCQL(t *testing.T, query string, wantFields ...string) {
+	t.Helper()
+	result, err := cql.Check(query)
+	if err != nil {
+		t.Fatalf("cql.Check(%s): %v", query, err)
+	}
+	if !reflect.DeepEqual(result.Fields, wantFields) {
+		t.Fatalf("cql.Check(%s) fields = %v, want %v", query, result.Fields, wantFields)
+	}
+}
+
+func TestBuildSearchCQL(t *testing.T) {
+	tests := []struct {
+		opts SearchOptions
+		want string
+	}{
+		{opts: SearchOptions{Query: "ADR"}, want: `text ~ "ADR"`},
+		{opts: SearchOptions{Query: "ADR", Types: []string{"comment"}, SpaceKeys: []string{"ENG"}}, want: `type=comment AND text ~ "ADR" AND space="ENG"`},
+		{opts: SearchOptions{Query: "ADR", Types: []string{"page", "blogpost"}, SpaceKeys: []string{"ENG", "OPS"}}, want: `type in (page,blogpost) AND text ~ "ADR" AND space in ("ENG","OPS")`},
+		{opts: SearchOptions{CQL: "type=attachment"}, want: "type=attachment"},
+	}
+	for _, tc := range tests {
+		cql, err := buildSearchCQL(tc.opts)
+		if err != nil || cql != tc.want {
+			t.Errorf("buildSearchCQL(%+v) = %q, %v; want %q", tc.opts, cql, err, tc.want)
+		}
+	}
+
+	for name, opts := range map[string]SearchOptions{
+		"nothing to search": {},
+		"types with cql":    {CQL: "type=page", Types: []string{"page"}},
+		"unknown type":      {Query: "x", Types: []string{"folder"}},
+	} {
+		if _, err := buildSearchCQL(opts); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSearchReturnsTypedResults(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/rest/api/search" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[
+			{"content":{"id":"501","type":"comment","title":"Re: ADR 12","space":{"id":98305,"key":"ENG"},"container":{"id":"400","type":"page","title":"ADR 12"}},
+			 "title":"Re: ADR 12","excerpt":"we chose @@@hl@@@Postgres@@@endhl@@@","url":"/spaces/ENG/pages/400?focusedCommentId=501","entityType":"content","lastModified":"2024-06-01T10:00:00.000Z"},
+			{"content":{"id":"att7","type":"attachment","title":"diagram.png","container":{"id":"400","type":"page","title":"ADR 12"}},
+			 "title":"diagram.png","url":"/download/attachments/400/diagram.png","entityType":"content","resultGlobalContainer":{"title":"Engineering","displayUrl":"/spaces/ENG"}},
+			{"content":{"id":"400","type":"page","title":"ADR 12","container":{"id":98305,"type":"space"}},"title":"ADR 12","entityType":"content"},
+			{"space":{"id":98305,"key":"ENG","name":"Engineering"},"title":"Engineering","url":"/spaces/ENG","entityType":"space","lastModified":"2024-05-01T00:00:00.000Z"}
+		],"_links":{"next":"/rest/api/search?cursor=next-1"}}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	result, err := client.Search(SearchOptions{Query: "ADR", Limit: 4})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got.Get("cql") != `text ~ "ADR"` || got.Get("expand") != searchExpand || got.Get("limit") != "4" {
+		t.Errorf("query = %v", got)
+	}
+	if len(result.Results) != 4 || result.NextCursor != "next-1" {
+		t.Fatalf("result = %+v", result)
+	}
+
+	comment := result.Results[0]
+	if comment.Type != "comment" || comment.SpaceKey != "ENG" || comment.Container == nil || comment.Container.ID != "400" || comment.Container.Title != "ADR 12" {
+		t.Errorf("comment = %+v", comment)
+	}
+	if comment.Excerpt != "we chose Postgres" || len(comment.Highlights) != 1 || comment.Highlights[0] != (Highlight{Start: 9, End: 17}) {
+		t.Errorf("excerpt = %q %v", comment.Excerpt, comment.Highlights)
+	}
+	if !comment.LastModified.Equal(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("lastModified = %v", comment.LastModified)
+	}
+	if attachment := result.Results[1]; attachment.SpaceKey != "ENG" || attachment.Container == nil || attachment.Container.Type != "page" {
+		t.Errorf("attachment = %+v", attachment)
+	}
+	if page := result.Results[2]; page.Type != "page" || page.Container != nil {
+		t.Errorf("page = %+v", page)
+	}
+	if space := result.Results[3]; space.ID != "98305" || space.Type != "space" || space.SpaceKey != "ENG" {
+		t.Errorf("space = %+v", space)
+	}
+}
+
+func TestCleanExcerpt(t *testing.T) {
+	tests := []struct {
+		raw        string
+		want       string
+		highlights []Highlight
+	}{
+		{raw: "Identifier\nCF-DD-2021-07-01\nDecision Status\nAPPROVED", want: "Identifier CF-DD-2021-07-01 Decision Status APPROVED"},
+		{raw: "we chose @@@hl@@@Postgres@@@endhl@@@ over &quot;Mongo&quot; &amp; co", want: `we chose Postgres over "Mongo" & co`, highlights: []Highlight{{Start: 9, End: 17}}},
+		{raw: "  @@@hl@@@caf\u00e9@@@endhl@@@ and\n\n @@@hl@@@caf&eacute;s@@@endhl@@@ ", want: "café and cafés", highlights: []Highlight{{Start: 0, End: 4}, {Start: 9, End: 14}}},
+		{raw: "x @@@hl@@@@@@endhl@@@ y @@@ z", want: "x y @@@ z"},
+		{raw: "unclosed @@@hl@@@match", want: "unclosed match", highlights: []Highlight{{Start: 9, End: 14}}},
+	}
+	for _, tc := range tests {
+		got, highlights := cleanExcerpt(tc.raw)
+		if got != tc.want || !reflect.DeepEqual(highlights, tc.highlights) {
+			t.Errorf("cleanExcerpt(%q) = %q %v, want %q %v", tc.raw, got, highlights, tc.want, tc.highlights)
+		}
+	}
+}
+
+func TestSearchPagesWithSpaceAndAncestors(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[{"content":{"id":"400","type":"page","title":"ADR 12",
+			"space":{"id":98305,"key":"ENG","name":"Engineering"},
+			"ancestors":[{"id":"1","type":"page","title":"Home"},{"id":"2","type":"page","title":"Decisions"}]},
+			"lastModified":"2024-06-01T10:00:00.000Z"}],"_links":{}}`))
+	}))
+	defer srv.Close()
+
+	result, err := newTestClient(srv.URL).SearchPages(PageSearchOptions{Query: "ADR", WithSpace: true, WithAncestors: true})
+	if err != nil {
+		t.Fatalf("SearchPages: %v", err)
+	}
+	if got.Get("expand") != "content.space,content.ancestors" {
+		t.Errorf("expand = %q", got.Get("expand"))
+	}
+	hit := result.Results[0]
+	if hit.SpaceID != "98305" || hit.SpaceKey != "ENG" || hit.SpaceName != "Engineering" {
+		t.Errorf("space = %q %q %q", hit.SpaceID, hit.SpaceKey, hit.SpaceName)
+	}
+	if len(hit.Ancestors) != 2 || hit.Ancestors[1].Title != "Decisions" {
+		t.Errorf("ancestors = %+v", hit.Ancestors)
+	}
+	if !hit.LastModified.Equal(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("lastModified = %v", hit.LastModified)
+	}
+
+	if _, err := newTestClient(srv.URL).SearchPages(PageSearchOptions{Query: "ADR"}); err != nil || got.Has("expand") {
+		t.Errorf("plain search expand = %q, %v", got.Get("expand"), err)
+	}
 }
@@ -0,0 +1,139 @@
+package confluence
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FlavorCloud  = "cloud"
+	FlavorServer = "server"
+)
+
+// normalizeServerBaseURL keeps any context path (for example /confluence) and
+// points at the Data Center REST v1 root.
+func normalizeServerBaseURL(baseURL string) string {
+	baseURL = strings.TrimSpace(strings.TrimRight(baseURL, "/"))
+	baseURL = strings.TrimSuffix(baseURL, "/rest/api")
+	return baseURL + "/rest/api"
+}
+
+func (c *Client) isServer() bool {
+	return c.flavor == FlavorServer
+}
+
+// serverContent is the Data Center /rest/api/content shape for pages.
+type serverContent struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Title  string `json:"title"`
+	Space  *struct {
+		ID   json.Number `json:"id"`
+		Key  string      `json:"key"`
+		Name string      `json:"name"`
+	} `json:"space,omitempty"`
+	History *struct {
+		CreatedDate time.Time `json:"createdDate"`
+		CreatedBy   struct {
+			UserKey string `json:"userKey"`
+		} `json:"createdBy"`
+	} `json:"history,omitempty"`
+	Version *struct {
+		Number  int       `json:"number"`
+		Message string    `json:"message"`
+		When    time.Time `json:"when"`
+		By      struct {
+			UserKey string `json:"userKey"`
+		} `json:"by"`
+	} `json:"version,omitempty"`
+	Ancestors []struct {
+		ID    string `json:"id"`
+		Type  string `json:"type"`
+		Title string `json:"title"`
+	} `json:"ancestors,omitempty"`
+	Body *struct {
+		View    *BodyRepresentation `json:"view,omitempty"`
+		Storage *BodyRepresentation `json:"storage,omitempty"`
+	} `json:"body,omitempty"`
+	Links struct {
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// serverPage is the Data Center offset-paginated response envelope.
+type serverPage[T any] struct {
+	Results []T `json:"results"`
+	Start   int `json:"start"`
+	Links   struct {
+		Next string `json:"next"`
+	} `json:"_links"`
+}
+
+// nextCursor encodes the next offset as the opaque cursor so CLI pagination
+// works the same way as the cloud cursor contract.
+func (p serverPage[T]) nextCursor() string {
+	if p.Links.Next == "" {
+		return ""
+	}
+	return strconv.Itoa(p.Start + len(p.Results))
+}
+
+func serverPaging(query url.Values, limit int, cursor string) error {
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if cursor == "" {
+		return nil
+	}
+	start, err := strconv.Atoi(cursor)
+	if err != nil || start < 0 {
+		return fmt.Errorf("invalid cursor %q for Data Center pagination", cursor)
+	}
+	query.Set("start", strconv.Itoa(start))
+	return nil
+}
+
+func (content serverContent) page() Page {
+	page := Page{
+		ID:     content.ID,
+		Title:  content.Title,
+		Status: content.Status,
+	}
+	if content.Space != nil {
+		page.SpaceID = content.Space.ID.String()
+	}
+	if n := len(content.Ancestors); n > 0 {
+		page.ParentID = content.Ancestors[n-1].ID
+		page.ParentType = content.Ancestors[n-1].Type
+	}
+	if content.History != nil {
+		page.AuthorID = content.History.CreatedBy.UserKey
+		page.CreatedAt = content.History.CreatedDate
+	}
+	if content.Version != nil {
+		page.Version = &Version{
+			Number:    content.Version.Number,
+			Message:   content.Version.Message,
+			CreatedAt: content.Version.When,
+			AuthorID:  content.Version.By.UserKey,
+		}
+	}
+	if content.Body != nil {
+		page.Body = &Body{View: content.Body.View, Storage: content.Body.Storage}
+	}
+	return page
+}
+
+// ancestors lists the expanded ancestors, which Data Center returns root first.
+func (content serverContent) ancestors() []ContentRef {
+	ancestors := make([]ContentRef, len(content.Ancestors))
+	for i, ancestor := range content.Ancestors {
+		ancestors[i] = ContentRef{ID: ancestor.ID, Type: ancestor.Type, Title: ancestor.Title}
+	}
+	return ancestors
+}
@@ -0,0 +1,223 @@
+package confluence
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+func (c *Client) serverContentList(path string, query url.Values, action string) (*serverPage[serverContent], error) {
+	body, err := c.doV1("GET", path, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", action, err)
+	}
+
+	var raw serverPage[serverContent]
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("parsing %s response: %w", action, err)
+	}
+	return &raw, nil
+}
+
+func serverPageList(raw *serverPage[serverContent]) *ListResult[Page] {
+	pages := make([]Page, len(raw.Results))
+	for i, content := range raw.Results {
+		pages[i] = content.page()
+	}
+	return &ListResult[Page]{Results: pages, NextCursor: raw.nextCursor()}
+}
+
+const serverPageExpand = "version,space,ancestors,history"
+
+func (c *Client) listPagesServer(opts ListPagesOptions) (*ListResult[Page], error) {
+	if opts.Status != "" && opts.Status != "current" {
+		return nil, fmt.Errorf("status %q is not available for Data Center; CQL lists current pages only", opts.Status)
+	}
+	cql, err := pageListCQL(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("cql", cql)
+	query.Set("expand", serverPageExpand)
+	if err := serverPaging(query, opts.Limit, opts.Cursor); err != nil {
+		return nil, err
+	}
+
+	raw, err := c.serverContentList("/content/search", query, "listing pages")
+	if err != nil {
+		return nil, err
+	}
+	return serverPageList(raw), nil
+}
+
+// serverPageIDsPerRequest keeps id-in CQL queries with body expansion well
+// under Data Center's response size limits.
+const serverPageIDsPerRequest = 50
+
+func serverPageExpandFor(bodyFormat string) (string, error) {
+	switch bodyFormat {
+	case "":
+		return serverPageExpand, nil
+	case "view", "storage":
+		return serverPageExpand + ",body." + bodyFormat, nil
+	default:
+		return "", fmt.Errorf("body format %q is not available on Confluence Data Center", bodyFormat)
+	}
+}
+
+func (c *Client) getPageServer(opts GetPageOptions) (*Page, error) {
+	expand, err := serverPageExpandFor(opts.BodyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("expand", expand)
+	body, err := c.doV1("GET", "/content/"+url.PathEscape(opts.PageID), query)
+	if err != nil {
+		return nil, fmt.Errorf("getting page: %w", err)
+	}
+
+	var content serverContent
+	if err := json.Unmarshal(body, &content); err != nil {
+		return nil, fmt.Errorf("parsing page: %w", err)
+	}
+	page := content.page()
+	return &page, nil
+}
+
+// getPagesServer looks up numeric page IDs with one id-in CQL search,
+// following offset pagination until every match is read.
+func (c *Client) getPagesServer(ids []string, bodyFormat string) ([]Page, error) {
+	expand, err := serverPageExpandFor(bodyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("cql", "type=page AND id in ("+strings.Join(ids, ",")+")")
+	query.Set("expand", expand)
+	var pages []Page
+	for cursor := ""; ; {
+		if err := serverPaging(query, len(ids), cursor); err != nil {
+			return nil, err
+		}
+		raw, err := c.serverContentList("/content/search", query, "getting pages")
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, serverPageList(raw).Results...)
+		if cursor = raw.nextCursor(); cursor == "" {
+			return pages, nil
+		}
+	}
+}
+
+func (c *Client) getPageChildrenServer(opts GetPageChildrenOptions) (*ListResult[Page], error) {
+	query := url.Values{}
+	query.Set("expand", "version,space")
+	if err := serverPaging(query, opts.Limit, opts.Cursor); err != nil {
+		return nil, err
+	}
+
+	raw, err := c.serverContentList("/content/"+url.PathEscape(opts.PageID)+"/child/page", query, "getting page children")
+	if err != nil {
+		return nil, err
+	}
+	result := serverPageList(raw)
+	for i := range result.Results {
+		result.Results[i].ParentID = opts.PageID
+		result.Results[i].ParentType = "page"
+	}
+	return result, nil
+}
+
+func (c *Client) searchPagesServer(cql string, opts PageSearchOptions) (*ListResult[SearchResult], error) {
+	query := url.Values{}
+	query.Set("cql", cql)
+	expand := "space,version"
+	if opts.WithAncestors {
+		expand += ",ancestors"
+	}
+	query.Set("expand", expand)
+	if err := serverPaging(query, opts.Limit, opts.Cursor); err != nil {
+		return nil, err
+	}
+
+	raw, err := c.serverContentList("/content/search", query, "searching pages")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, len(raw.Results))
+	for i, content := range raw.Results {
+		results[i] = SearchResult{
+			ID:    content.ID,
+			Title: content.Title,
+			Type:  content.Type,
+			URL:   content.Links.WebUI,
+		}
+		if content.Space != nil {
+			results[i].SpaceID = content.Space.ID.String()
+			results[i].SpaceKey = content.Space.Key
+			results[i].SpaceName = content.Space.Name
+		}
+		if content.Version != nil {
+			results[i].LastModified = content.Version.When
+		}
+		if len(content.Ancestors) > 0 {
+			results[i].Ancestors = content.ancestors()
+		}
+	}
+	return &ListResult[SearchResult]{Results: results, NextCursor: raw.nextCursor()}, nil
+}
+
+func (c *Client) getPageAncestorsServer(pageID string) ([]ContentRef, error) {
+	query := url.Values{}
+	query.Set("expand", "ancestors")
+	body, err := c.doV1("GET", "/content/"+url.PathEscape(pageID), query)
+	if err != nil {
+		return nil, fmt.Errorf("getting page ancestors: %w", err)
+	}
+	var content serverContent
+	if err := json.Unmarshal(body, &content); err != nil {
+		return nil, fmt.Errorf("parsing page ancestors: %w", err)
+	}
+	return content.ancestors(), nil
+}
+
+// getPageDescendantsServer derives depth and parent from each descendant's
+// ancestors. Data Center has no depth parameter, so deeper pages are dropped
+// after fetching and a page of results may hold fewer than the limit.
+func (c *Client) getPageDescendantsServer(opts GetPageDescendantsOptions) (*ListResult[Descendant], error) {
+	query := url.Values{}
+	query.Set("expand", "ancestors")
+	if err := serverPaging(query, opts.Limit, opts.Cursor); err != nil {
+		return nil, err
+	}
+
+	raw, err := c.serverContentList("/content/"+url.PathEscape(opts.PageID)+"/descendant/page", query, "getting page descendants")
+	if err != nil {
+		return nil, err
+	}
+	descendants := []Descendant{}
+	for _, content := range raw.Results {
+		descendant := Descendant{ID: content.ID, Type: content.Type, Title: content.Title, Status: content.Status}
+		for i, ancestor := range content.Ancestors {
+			if ancestor.ID == opts.PageID {
+				descendant.Depth = len(content.Ancestors) - i
+			}
+		}
+		if n := len(content.Ancestors); n > 0 {
+			descendant.ParentID = content.Ancestors[n-1].ID
+		}
+		if opts.Depth > 0 && descendant.Depth > opts.Depth {
+			continue
+		}
+		descendants = append(descendants, descendant)
+	}
+	return &ListResult[Descendant]{Results: descendants, NextCursor: raw.nextCursor()}, nil
+}
@@ -0,0 +1,128 @@
+package confluence
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// serverSpace is a Data Center v1 space with the description, homepage, and
+// label expansions.
+type serverSpace struct {
+	ID          json.Number `json:"id"`
+	Key         string      `json:"key"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Status      string      `json:"status"`
+	Description struct {
+		Plain struct {
+			Value string `json:"value"`
+		} `json:"plain"`
+	} `json:"description"`
+	Homepage *struct {
+		ID string `json:"id"`
+	} `json:"homepage,omitempty"`
+	Metadata struct {
+		Labels struct {
+			Results []struct {
+				Name string `json:"name"`
+			} `json:"results"`
+		} `json:"labels"`
+	} `json:"metadata"`
+}
+
+func (raw serverSpace) space() Space {
+	space := Space{
+		ID:          raw.ID.String(),
+		Key:         raw.Key,
+		Name:        raw.Name,
+		Type:        raw.Type,
+		Status:      raw.Status,
+		Description: raw.Description.Plain.Value,
+	}
+	if raw.Homepage != nil {
+		space.HomepageID = raw.Homepage.ID
+	}
+	for _, label := range raw.Metadata.Labels.Results {
+		space.Labels = append(space.Labels, label.Name)
+	}
+	return space
+}
+
+func (c *Client) listSpacesServer(opts ListSpacesOptions) (*ListResult[Space], error) {
+	if opts.Sort != "" {
+		return nil, fmt.Errorf("sort is not supported for Data Center spaces")
+	}
+
+	query := url.Values{}
+	query.Set("expand", "description.plain,homepage")
+	for _, key := range opts.Keys {
+		query.Add("spaceKey", key)
+	}
+	if opts.Type != "" {
+		query.Set("type", opts.Type)
+	}
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+	for _, label := range opts.Labels {
+		query.Add("label", label)
+	}
+	if opts.Favourite {
+		query.Set("favourite", "true")
+	}
+	if err := serverPaging(query, opts.Limit, opts.Cursor); err != nil {
+		return nil, err
+	}
+
+	body, err := c.doV1("GET", "/space", query)
+	if err != nil {
+		return nil, fmt.Errorf("listing spaces: %w", err)
+	}
+
+	var raw serverPage[serverSpace]
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("parsing spaces response: %w", err)
+	}
+
+	spaces := make([]Space, len(raw.Results))
+	for i, space := range raw.Results {
+		spaces[i] = space.space()
+	}
+	return &ListResult[Space]{Results: spaces, NextCursor: raw.nextCursor()}, nil
+}
+
+// getSpaceServer reads /space/{key}, or filters /space by spaceId because the
+// v1 API has no get-by-ID endpoint.
+func (c *Client) getSpaceServer(opts GetSpaceOptions) (*Space, error) {
+	query := url.Values{}
+	query.Set("expand", "description.plain,homepage,metadata.labels")
+
+	if opts.SpaceID == "" {
+		body, err := c.doV1("GET", "/space/"+url.PathEscape(opts.SpaceKey), query)
+		if err != nil {
+			return nil, fmt.Errorf("getting space: %w", err)
+		}
+		var raw serverSpace
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("parsing space: %w", err)
+		}
+		space := raw.space()
+		return &space, nil
+	}
+
+	query.Set("spaceId", opts.SpaceID)
+	body, err := c.doV1("GET", "/space", query)
+	if err != nil {
+		return nil, fmt.Errorf("getting space: %w", err)
+	}
+	var raw serverPage[serverSpace]
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("parsing space: %w", err)
+	}
+	if len(raw.Results) == 0 {
+		return nil, fmt.Errorf("getting space: %w", &APIError{StatusCode: 404, Message: "no space with id " + opts.SpaceID})
+	}
+	space := raw.Results[0].space()
+	return &space, nil
+}
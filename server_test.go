@@ -0,0 +1,373 @@
+package confluence
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serverTestServer serves Data Center fixtures under a /confluence context path
+// and records the last query string per path.
+func serverTestServer(t *testing.T, routes map[string]string, queries map[string]url.Values) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pat-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"statusCode":401,"message":"not authenticated"}`))
+			return
+		}
+		fixture, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"statusCode":404,"message":"No content found"}`))
+			return
+		}
+		if queries != nil {
+			queries[r.URL.Path] = r.URL.Query()
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", fixture))
+		if err != nil {
+			t.Fatalf("reading fixture %s: %v", fixture, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}))
+}
+
+func newServerTestClient(serverURL string) *Client {
+	return NewClient(Options{
+		BaseURL:       serverURL + "/confluence/",
+		Flavor:        FlavorServer,
+		Authenticator: BearerAuth{Token: "pat-token"},
+	})
+}
+
+func TestNormalizeServerBaseURL(t *testing.T) {
+	tests := map[string]string{
+		"https://confluence.example.com":                     "https://confluence.example.com/rest/api",
+		"https://confluence.example.com/confluence/":         "https://confluence.example.com/confluence/rest/api",
+		"https://confluence.example.com/confluence/rest/api": "https://confluence.example.com/confluence/rest/api",
+	}
+	for input, want := range tests {
+		if got := normalizeServerBaseURL(input); got != want {
+			t.Errorf("normalizeServerBaseURL(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestServerListPages(t *testing.T) {
+	queries := map[string]url.Values{}
+	srv := serverTestServer(t, map[string]string{
+		"/confluence/rest/api/content/search": "server_content_search.json",
+	}, queries)
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	result, err := client.ListPages(ListPagesOptions{SpaceID: "98305", Limit: 2, Cursor: "4", Sort: "-modified-date"})
+	if err != nil {
+		t.Fatalf("ListPages: %v", err)
+	}
+
+	q := queries["/confluence/rest/api/content/search"]
+	if got := q.Get("cql"); got != "type=page AND space.id=98305 ORDER BY lastmodified desc" {
+		t.Errorf("cql = %q", got)
+	}
+	if q.Get("start") != "4" || q.Get("limit") != "2" {
+		t.Errorf("start/limit = %q/%q, want 4/2", q.Get("start"), q.Get("limit"))
+	}
+
+	if len(result.Results) != 2 {
+		t.Fatalf("expected 2 pages, got %d", len(result.Results))
+	}
+	p := result.Results[0]
+	if p.ID != "65601" || p.SpaceID != "98305" || p.Status != "current" {
+		t.Errorf("page = %+v", p)
+	}
+	if p.ParentID != "65590" || p.ParentType != "page" {
+		t.Errorf("parent = %q/%q, want 65590/page", p.ParentID, p.ParentType)
+	}
+	if p.Version == nil || p.Version.Number != 12 || p.Version.AuthorID != "8a7f8081" {
+		t.Errorf("version = %+v", p.Version)
+	}
+	if p.AuthorID != "8a7f8081" || p.CreatedAt.IsZero() {
+		t.Errorf("author/created = %q/%v", p.AuthorID, p.CreatedAt)
+	}
+	if result.Results[1].ParentID != "" {
+		t.Errorf("page[1] ParentID = %q, want empty", result.Results[1].ParentID)
+	}
+	// fixture start=0 with two results and a next link
+	if result.NextCursor != "2" {
+		t.Errorf("NextCursor = %q, want %q", result.NextCursor, "2")
+	}
+}
+
+func TestServerGetPage(t *testing.T) {
+	queries := map[string]url.Values{}
+	srv := serverTestServer(t, map[string]string{
+		"/confluence/rest/api/content/65601": "server_page_get.json",
+	}, queries)
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	page, err := client.GetPage(GetPageOptions{PageID: "65601", BodyFormat: "view"})
+	if err != nil {
+		t.Fatalf("GetPage: %v", err)
+	}
+	if got := queries["/confluence/rest/api/content/65601"].Get("expand"); !strings.HasSuffix(got, ",body.view") {
+		t.Errorf("expand = %q, want body.view", got)
+	}
+	if page.Body == nil || page.Body.View == nil || !strings.Contains(page.Body.View.Value, "Restart the service") {
+		t.Fatalf("body = %+v", page.Body)
+	}
+	if page.ParentID != "65537" {
+		t.Errorf("ParentID = %q, want %q", page.ParentID, "65537")
+	}
+
+	if _, err := client.GetPage(GetPageOptions{PageID: "65601", BodyFormat: "atlas_doc_format"}); err == nil {
+		t.Fatal("expected atlas_doc_format to be rejected on Data Center")
+	}
+}
+
+func TestServerGetPageChildren(t *testing.T) {
+	srv := serverTestServer(t, map[string]string{
+		"/confluence/rest/api/content/65537/child/page": "server_content_search.json",
+	}, nil)
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	result, err := client.GetPageChildren(GetPageChildrenOptions{PageID: "65537", Limit: 2})
+	if err != nil {
+		t.Fatalf("GetPageChildren: %v", err)
+	}
+	for _, child := range result.Results {
+		if child.ParentID != "65537" {
+			t.Errorf("child %s ParentID = %q, want %q", child.ID, child.ParentID, "65537")
+		}
+	}
+	if result.NextCursor == "" {
+		t.Error("expected non-empty NextCursor")
+	}
+}
+
+func TestServerSearchPages(t *testing.T) {
+	queries := map[string]url.Values{}
+	srv := serverTestServer(t, map[string]string{
+		"/confluence/rest/api/content/search": "server_content_search.json",
+	}, queries)
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	result, err := client.SearchPages(PageSearchOptions{Query: "runbook", SpaceKey: "ENG", Limit: 2})
+	if err != nil {
+		t.Fatalf("SearchPages: %v", err)
+	}
+	if got := queries["/confluence/rest/api/content/search"].Get("cql"); got != `type=page AND text ~ "runbook" AND space="ENG"` {
+		t.Errorf("cql = %q", got)
+	}
+	r := result.Results[0]
+	if r.ID != "65601" || r.Type != "page" || r.SpaceID != "98305" || r.URL != "/display/ENG/Platform+Runbook" {
+		t.Errorf("result = %+v", r)
+	}
+}
+
+func TestServerListSpaces(t *testing.T) {
+	srv := serverTestServer(t, map[string]string{
+		"/confluence/rest/api/space": "server_spaces_list.json",
+	}, nil)
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	result, err := client.ListSpaces(ListSpacesOptions{Limit: 25})
+	if err != nil {
+		t.Fatalf("ListSpaces: %v", err)
+	}
+	if len(result.Results) != 1 {
+		t.Fatalf("expected 1 space, got %d", len(result.Results))
+	}
+	s := result.Results[0]
+	if s.ID != "98305" || s.Key != "ENG" || s.HomepageID != "65537" || s.Description != "Engineering handbook" {
+		t.Errorf("space = %+v", s)
+	}
+	if result.NextCursor != "" {
+		t.Errorf("NextCursor = %q, want empty", result.NextCursor)
+	}
+}
+
+func TestServerRejectsNonNumericCursor(t *testing.T) {
+	client := newServerTestClient("http://127.0.0.1:0")
+	if _, err := client.ListSpaces(ListSpacesOptions{Cursor: "eyJpZCI6MX0="}); err == nil {
+		t.Fatal("expected invalid cursor error")
+	}
+}
+
+func TestServerGetPages(t *testing.T) {
+	var gotCQL, gotExpand string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCQL = r.URL.Query().Get("cql")
+		gotExpand = r.URL.Query().Get("expand")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[{"id":"65601","type":"page","status":"current","title":"Platform Runbook","space":{"id":98305,"key":"ENG"}}],"start":0,"size":1,"_links":{}}`))
+	}))
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	result, err := client.GetPages(GetPagesOptions{PageIDs: []string{"65601", "65999"}, BodyFormat: "storage"})
+	if err != nil {
+		t.Fatalf("GetPages: %v", err)
+	}
+	if gotCQL != "type=page AND id in (65601,65999)" {
+		t.Errorf("cql = %q", gotCQL)
+	}
+	if !strings.HasSuffix(gotExpand, ",body.storage") {
+		t.Errorf("expand = %q", gotExpand)
+	}
+	if len(result.Pages) != 1 || result.Pages[0].SpaceID != "98305" || strings.Join(result.NotFound, ",") != "65999" {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+func TestServerGetSpace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("expand"); got != "description.plain,homepage,metadata.labels" {
+			t.Errorf("expand = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		space := `{"id":98305,"key":"ENG","name":"Engineering","type":"global","status":"current",
+			"description":{"plain":{"value":"Runbooks"}},"homepage":{"id":"65590"},
+			"metadata":{"labels":{"results":[{"name":"team"}]}}}`
+		switch {
+		case r.URL.Path == "/confluence/rest/api/space/ENG":
+			_, _ = w.Write([]byte(space))
+		case r.URL.Path == "/confluence/rest/api/space" && r.URL.Query().Get("spaceId") == "98305":
+			_, _ = w.Write([]byte(`{"results":[` + space + `],"start":0,"limit":25,"size":1,"_links":{}}`))
+		case r.URL.Path == "/confluence/rest/api/space":
+			_, _ = w.Write([]byte(`{"results":[],"start":0,"limit":25,"size":0,"_links":{}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	for _, opts := range []GetSpaceOptions{{SpaceKey: "ENG"}, {SpaceID: "98305"}} {
+		space, err := client.GetSpace(opts)
+		if err != nil {
+			t.Fatalf("GetSpace(%+v): %v", opts, err)
+		}
+		if space.ID != "98305" || space.HomepageID != "65590" || space.Description != "Runbooks" || len(space.Labels) != 1 {
+			t.Errorf("GetSpace(%+v) = %+v", opts, space)
+		}
+	}
+	if _, err := client.GetSpace(GetSpaceOptions{SpaceID: "1"}); err == nil {
+		t.Error("expected error for unknown space ID")
+	}
+}
+
+func TestServerListSpacesFilters(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[],"start":0,"limit":10,"size":0,"_links":{}}`))
+	}))
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	_, err := client.ListSpaces(ListSpacesOptions{Type: "personal", Status: "archived", Labels: []string{"a", "b"}, Favourite: true, Limit: 10})
+	if err != nil {
+		t.Fatalf("ListSpaces: %v", err)
+	}
+	if got.Get("type") != "personal" || got.Get("status") != "archived" || got.Get("favourite") != "true" {
+		t.Errorf("query = %v", got)
+	}
+	if labels := got["label"]; len(labels) != 2 || labels[0] != "a" || labels[1] != "b" {
+		t.Errorf("label = %v", labels)
+	}
+	if _, err := client.ListSpaces(ListSpacesOptions{Sort: "name"}); err == nil {
+		t.Error("expected sort to be rejected for Data Center")
+	}
+}
+
+func TestServerListPagesFilters(t *testing.T) {
+	var gotCQL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCQL = r.URL.Query().Get("cql")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[],"start":0,"limit":10,"size":0,"_links":{}}`))
+	}))
+	defer srv.Close()
+
+	client := newServerTestClient(srv.URL)
+	_, err := client.ListPages(ListPagesOptions{
+		SpaceID:       "98305",
+		Title:         `Say "hi"`,
+		CreatedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBefore: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Sort:          "title",
+	})
+	if err != nil {
+		t.Fatalf("ListPages: %v", err)
+	}
+	want := `type=page AND space.id=98305 AND title="Say \"hi\"" AND created >= "2024-01-01" AND created < "2024-02-01" ORDER BY title`
+	if gotCQL != want {
+		t.Errorf("cql = %q, want %q", gotCQL, want)
+	}
+	if _, err := client.ListPages(ListPagesOptions{Status: "trashed"}); err == nil {
+		t.Error("expected non-current status to be rejected for Data Center")
+	}
+}
+
+func TestServerSearch(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/confluence/rest/api/search" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[
+			{"content":{"id":"501","type":"comment","title":"Re: ADR 12","container":{"id":"400","type":"page","title":"ADR 12"}},
+			 "title":"Re: ADR 12","entityType":"content","resultGlobalContainer":{"title":"Engineering","displayUrl":"/display/ENG"}}
+		],"start":10,"limit":1,"size":1,"_links":{"next":"/rest/api/search?start=11"}}`))
+	}))
+	defer srv.Close()
+
+	result, err := newServerTestClient(srv.URL).Search(SearchOptions{Query: "ADR", Types: []string{"comment"}, Limit: 1, Cursor: "10"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got.Get("start") != "10" || got.Get("limit") != "1" || got.Get("cql") != `type=comment AND text ~ "ADR"` {
+		t.Errorf("query = %v", got)
+	}
+	if result.NextCursor != "11" || result.Results[0].SpaceKey != "ENG" || result.Results[0].Container.ID != "400" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestServerSearchPagesWithAncestors(t *testing.T) {
+	queries := map[string]url.Values{}
+	srv := serverTestServer(t, map[string]string{
+		"/confluence/rest/api/content/search": "server_content_search.json",
+	}, queries)
+	defer srv.Close()
+
+	result, err := newServerTestClient(srv.URL).SearchPages(PageSearchOptions{Query: "runbook", WithAncestors: true})
+	if err != nil {
+		t.Fatalf("SearchPages: %v", err)
+	}
+	if got := queries["/confluence/rest/api/content/search"].Get("expand"); got != "space,version,ancestors" {
+		t.Errorf("expand = %q", got)
+	}
+	hit := result.Results[0]
+	if hit.SpaceKey != "ENG" || hit.SpaceName != "Engineering" || len(hit.Ancestors) != 2 || hit.Ancestors[0].Title != "Engineering Home" {
+		t.Errorf("hit = %+v", hit)
+	}
+	if !hit.LastModified.Equal(time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("lastModified = %v", hit.LastModified)
+	}
+}
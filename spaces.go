@@ -5,15 +5,48 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 type ListSpacesOptions struct {
-	Limit  int
-	Cursor string
+	Keys      []string
+	Type      string // "global" or "personal"
+	Status    string // "current" or "archived"
+	Labels    []string
+	Favourite bool // only spaces the caller marked as favourite
+	Sort      string
+	Limit     int
+	Cursor    string
 }
 
 func (c *Client) ListSpaces(opts ListSpacesOptions) (*ListResult[Space], error) {
+	if c.isServer() {
+		return c.listSpacesServer(opts)
+	}
+
 	query := url.Values{}
+	if len(opts.Keys) > 0 {
+		query.Set("keys", strings.Join(opts.Keys, ","))
+	}
+	if opts.Type != "" {
+		query.Set("type", opts.Type)
+	}
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+	if len(opts.Labels) > 0 {
+		query.Set("labels", strings.Join(opts.Labels, ","))
+	}
+	if opts.Favourite {
+		accountID, err := c.currentAccountID()
+		if err != nil {
+			return nil, fmt.Errorf("listing spaces: %w", err)
+		}
+		query.Set("favorited-by", accountID)
+	}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+	}
 	if opts.Limit > 0 {
 		query.Set("limit", strconv.Itoa(opts.Limit))
 	}
@@ -41,3 +74,77 @@ func (c *Client) ListSpaces(opts ListSpacesOptions) (*ListResult[Space], error)
 		NextCursor: extractCursor(raw.Links.Next),
 	}, nil
 }
+
+// currentAccountID returns the Atlassian account ID of the authenticated
+// user, which v2 needs to filter favourites.
+func (c *Client) currentAccountID() (string, error) {
+	body, err := c.doV1("GET", "/user/current", nil)
+	if err != nil {
+		return "", fmt.Errorf("getting current user: %w", err)
+	}
+	var user struct {
+		AccountID string `json:"accountId"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return "", fmt.Errorf("parsing current user: %w", err)
+	}
+	return user.AccountID, nil
+}
+
+// GetSpaceOptions selects one space by ID or by key.
+type GetSpaceOptions struct {
+	SpaceID  string
+	SpaceKey string
+}
+
+// GetSpace returns a space with its plain-text description and labels. A key
+// is looked up through /spaces?keys= before the detail request.
+func (c *Client) GetSpace(opts GetSpaceOptions) (*Space, error) {
+	if c.isServer() {
+		return c.getSpaceServer(opts)
+	}
+
+	id := opts.SpaceID
+	if id == "" {
+		found, err := c.FindSpaceID(opts.SpaceKey)
+		if err != nil {
+			return nil, fmt.Errorf("getting space: %w", err)
+		}
+		if found == "" {
+			return nil, fmt.Errorf("getting space: %w", &APIError{StatusCode: 404, Message: "no space with key " + opts.SpaceKey})
+		}
+		id = found
+	}
+
+	query := url.Values{}
+	query.Set("description-format", "plain")
+	query.Set("include-labels", "true")
+	body, err := c.do("GET", "/spaces/"+url.PathEscape(id), query)
+	if err != nil {
+		return nil, fmt.Errorf("getting space: %w", err)
+	}
+
+	var raw struct {
+		Space
+		Description struct {
+			Plain struct {
+				Value string `json:"value"`
+			} `json:"plain"`
+		} `json:"description"`
+		Labels struct {
+			Results []struct {
+				Name string `json:"name"`
+			} `json:"results"`
+		} `json:"labels"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("parsing space: %w", err)
+	}
+
+	space := raw.Space
+	space.Description = raw.Description.Plain.Value
+	for _, label := range raw.Labels.Results {
+		space.Labels = append(space.Labels, label.Name)
+	}
+	return &space, nil
+}
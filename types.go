@@ -10,6 +10,7 @@ type Space struct {
 	Status      string    `json:"status"`
 	HomepageID  string    `json:"homepageId,omitempty"`
 	Description string    `json:"description,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
 	CreatedAt   time.Time `json:"createdAt,omitempty"`
 }
 
@@ -45,12 +46,56 @@ type BodyRepresentation struct {
 }
 
 type SearchResult struct {
-	ID      string `json:"id"`
-	Title   string `json:"title"`
-	Type    string `json:"type"`
-	SpaceID string `json:"spaceId,omitempty"`
-	Excerpt string `json:"excerpt,omitempty"`
-	URL     string `json:"url,omitempty"`
+	ID           string       `json:"id"`
+	Title        string       `json:"title"`
+	Type         string       `json:"type"`
+	SpaceID      string       `json:"spaceId,omitempty"`
+	SpaceKey     string       `json:"spaceKey,omitempty"`
+	SpaceName    string       `json:"spaceName,omitempty"`
+	Excerpt      string       `json:"excerpt,omitempty"`
+	Highlights   []Highlight  `json:"highlights,omitempty"`
+	URL          string       `json:"url,omitempty"`
+	LastModified time.Time    `json:"lastModified,omitempty"`
+	Ancestors    []ContentRef `json:"ancestors,omitempty"` // root first
+}
+
+// Highlight marks a matched span of an excerpt as [Start, End) character
+// offsets.
+type Highlight struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// ContentResult is one typed search hit. Comments and attachments carry the
+// page or blog post that holds them as their container.
+type ContentResult struct {
+	ID           string      `json:"id"`
+	Type         string      `json:"type"`
+	Title        string      `json:"title"`
+	SpaceKey     string      `json:"spaceKey,omitempty"`
+	Container    *ContentRef `json:"container,omitempty"`
+	LastModified time.Time   `json:"lastModified,omitempty"`
+	Excerpt      string      `json:"excerpt,omitempty"`
+	Highlights   []Highlight `json:"highlights,omitempty"`
+	URL          string      `json:"url,omitempty"`
+}
+
+// ContentRef identifies a page or blog post by ID, type, and title.
+type ContentRef struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+}
+
+// Descendant is one entry of a flat descendants listing. Depth is 1 for
+// direct children of the requested page.
+type Descendant struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	ParentID string `json:"parentId"`
+	Depth    int    `json:"depth"`
 }
 
 // ListResult is a generic paginated result